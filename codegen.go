@@ -2,13 +2,18 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"go/token"
 	"log"
 	"os"
 	"path"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/mijia/modelq/drivers"
 )
@@ -22,6 +27,207 @@ type CodeConfig struct {
 	packageName    string
 	touchTimestamp bool
 	template       string
+	commentEnums   bool
+	nullable       string
+	strict         bool
+	decimal        string
+	jsonTypes      map[string]string
+	columnTypes    map[string]string
+	structNames    map[string]string
+	fieldNames     map[string]string
+	columnTags     map[string]string
+	tags           []string
+	tagCase        string
+	omitempty      bool
+	validate       bool
+	sensitive      map[string]bool
+	naming         string
+	initialisms    map[string]bool
+	singular       bool
+	irregulars     map[string]string
+	stripPrefixes  []string
+	stripSuffixes  []string
+}
+
+// goName converts the table, column or enum label into the go name by the naming strategy, the default is
+// toCapitalCase and the "golint" one keeps the initialisms, e.g. user_id => UserID.
+func (cc CodeConfig) goName(name string) string {
+	if cc.naming == "golint" {
+		return toGolintCase(name, cc.initialisms)
+	}
+	return toCapitalCase(name)
+}
+
+// baseName strips the prefix and suffix of the table name and changes it into the singular if needed,
+// e.g. tbl_blog_posts => blog_post, which is the file name and the base of the struct name.
+func (cc CodeConfig) baseName(tName string) string {
+	name := tName
+	for _, prefix := range cc.stripPrefixes {
+		if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			name = name[len(prefix):]
+			break
+		}
+	}
+	for _, suffix := range cc.stripSuffixes {
+		if len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+			name = name[:len(name)-len(suffix)]
+			break
+		}
+	}
+	if cc.singular {
+		name = singularize(name, cc.irregulars)
+	}
+	return name
+}
+
+// fileName keeps the letters, digits and "_" of the base name, and avoids the names ignored by the go build
+// or having the build constraints, e.g. _migrations => migrations.go, user_test => user_test_model.go.
+func (cc CodeConfig) fileName(tName string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, cc.baseName(tName))
+	if name = strings.TrimLeft(name, "_-"); name == "" {
+		name = strings.ToLower(cc.modelName(tName))
+	}
+	if parts := strings.Split(name, "_"); len(parts) > 1 {
+		if last := strings.ToLower(parts[len(parts)-1]); last == "test" || kBuildSuffixes[last] {
+			name += "_model"
+		}
+	}
+	return name + ".go"
+}
+
+// kBuildSuffixes are the GOOS and GOARCH which would be the build constraints as the file name suffixes
+var kBuildSuffixes = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true, "illumos": true,
+	"ios": true, "js": true, "linux": true, "nacl": true, "netbsd": true, "openbsd": true, "plan9": true,
+	"solaris": true, "wasip1": true, "windows": true, "zos": true,
+	"386": true, "amd64": true, "arm": true, "arm64": true, "loong64": true, "mips": true, "mipsle": true,
+	"mips64": true, "mips64le": true, "ppc64": true, "ppc64le": true, "riscv64": true, "s390x": true,
+	"sparc64": true, "wasm": true,
+}
+
+// modelName is the struct name of the table, which could be set by -names or the config file
+func (cc CodeConfig) modelName(tName string) string {
+	if name, ok := cc.structNames[tName]; ok {
+		return name
+	}
+	return exportedName(cc.goName(cc.baseName(tName)))
+}
+
+// exportedName adds the "X" prefix if the name doesn't start with an upper case letter, e.g. 2FaCode => X2FaCode
+// for the column 2fa_code, or X名字 for the letters without the cases.
+func exportedName(name string) string {
+	if first, _ := utf8.DecodeRuneInString(name); name != "" && !unicode.IsUpper(first) {
+		return "X" + name
+	}
+	return name
+}
+
+// kModelMethods are the methods of the model struct, the fields could not have the same names
+var kModelMethods = map[string]bool{"String": true, "Get": true, "Insert": true, "Update": true, "Delete": true}
+
+// checkModelNames makes sure the structs and files of the tables are not the same, and the explicit struct
+// names are valid, which should be checked before generating any of the models.
+func (cc CodeConfig) checkModelNames(dbSchema drivers.DbSchema) error {
+	tableNames := make([]string, 0, len(dbSchema))
+	for tName := range dbSchema {
+		tableNames = append(tableNames, tName)
+	}
+	sort.Strings(tableNames)
+	names := make(map[string]string)
+	files := make(map[string]string)
+	for _, tName := range tableNames {
+		name := cc.modelName(tName)
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return fmt.Errorf("Cannot name the struct of the table %s, %q is not an exported identifier, please set it by -names", tName, name)
+		}
+		for _, ident := range modelIdents(name) {
+			if other, ok := names[ident]; ok {
+				return fmt.Errorf("The tables %s and %s have the same name %s in the generated code, please rename one by -names", other, tName, ident)
+			}
+			names[ident] = tName
+		}
+		file := strings.ToLower(cc.fileName(tName))
+		if other, ok := files[file]; ok {
+			return fmt.Errorf("The tables %s and %s would be generated into the same file %s", other, tName, cc.fileName(tName))
+		}
+		files[file] = tName
+	}
+	return nil
+}
+
+// modelIdents are the generated types and vars of the model, e.g. User, UserObjs and UserRowVisitor
+func modelIdents(name string) []string {
+	return []string{name, name + "Objs", name + "RowVisitor"}
+}
+
+// pluralize uses the irregulars in the reverse way for the singular names, e.g. Person => People
+func (cc CodeConfig) pluralize(name string) string {
+	if cc.singular {
+		words := splitWords(name)
+		if len(words) > 0 {
+			last := words[len(words)-1]
+			plurals := make([]string, 0, len(cc.irregulars))
+			for plural := range cc.irregulars {
+				plurals = append(plurals, plural)
+			}
+			sort.Strings(plurals)
+			for _, plural := range plurals {
+				if singular := cc.irregulars[plural]; plural != singular && strings.EqualFold(last, singular) {
+					return name[:len(name)-len(last)] + matchCase(plural, last)
+				}
+			}
+		}
+	}
+	return pluralize(name)
+}
+
+// newModelFields names the fields of the table by -names, the config file or the naming strategy, the names would
+// have the number suffixes if they are the same, e.g. user_id and UserId would be UserId and UserId2, and the ones
+// of the model methods would have the "Field" suffix, e.g. StringField for the column string. The columns without
+// any letter or digit are named by the position, e.g. Column3. The explicit names should be valid and not the same.
+func (cc CodeConfig) newModelFields(schema drivers.TableSchema) ([]ModelField, error) {
+	fields := make([]ModelField, len(schema))
+	used := make(map[string]bool)
+	for name := range kModelMethods {
+		used[name] = true
+	}
+	explicit := make(map[int]bool)
+	for i, col := range schema {
+		fields[i] = newModelField(col)
+		name, ok := cc.fieldNames[col.TableName+"."+col.ColumnName]
+		if !ok {
+			continue
+		}
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return nil, fmt.Errorf("The name %q of the column %s.%s is not an exported identifier", name, col.TableName, col.ColumnName)
+		}
+		if used[name] {
+			return nil, fmt.Errorf("The name %q of the column %s.%s is used by another column or the model methods", name, col.TableName, col.ColumnName)
+		}
+		fields[i].Name, used[name], explicit[i] = name, true, true
+	}
+	for i, col := range schema {
+		if explicit[i] {
+			continue
+		}
+		name := exportedName(cc.goName(col.ColumnName))
+		if name == "" {
+			name = fmt.Sprintf("Column%d", i+1)
+		}
+		if kModelMethods[name] {
+			name += "Field"
+		}
+		for n, base := 2, name; used[name]; n++ {
+			name = fmt.Sprintf("%s%d", base, n)
+		}
+		fields[i].Name, used[name] = name, true
+	}
+	return fields, nil
 }
 
 func (cc CodeConfig) MustCompileTemplate() *template.Template {
@@ -62,7 +268,25 @@ func (cc CodeConfig) MustCompileTemplate() *template.Template {
 	return template.Must(tmpl, err)
 }
 
-func generateModels(dbName string, dbSchema drivers.DbSchema, config CodeConfig) {
+func generateModels(dbName string, dbSchema drivers.DbSchema, config CodeConfig) error {
+	if err := config.checkModelNames(dbSchema); err != nil {
+		return err
+	}
+	enums, err := config.modelEnums(dbSchema)
+	if err != nil {
+		return err
+	}
+	tableNames := make([]string, 0, len(dbSchema))
+	for tName := range dbSchema {
+		tableNames = append(tableNames, tName)
+	}
+	sort.Strings(tableNames)
+	modelFields := make(map[string][]ModelField, len(dbSchema))
+	for _, tName := range tableNames {
+		if modelFields[tName], err = config.buildModelFields(tName, dbSchema[tName], enums); err != nil {
+			return err
+		}
+	}
 	customTmpl := config.MustCompileTemplate()
 
 	if fs, err := os.Stat(config.packageName); err != nil || !fs.IsDir() {
@@ -72,24 +296,70 @@ func generateModels(dbName string, dbSchema drivers.DbSchema, config CodeConfig)
 	jobs := make(chan CodeResult)
 	for tbl, cols := range dbSchema {
 		go func(tableName string, schema drivers.TableSchema) {
-			err := generateModel(dbName, tableName, schema, config, customTmpl)
+			err := generateModel(dbName, tableName, schema, dbSchema, modelFields, config, customTmpl)
 			jobs <- CodeResult{tableName, err}
 		}(tbl, cols)
 	}
 
+	// all the jobs are drained before returning the errors of the tables, which are sorted by the table names
+	failures := make([]CodeResult, 0)
 	for i := 0; i < len(dbSchema); i++ {
 		result := <-jobs
 		if result.err != nil {
 			log.Printf("Error when generating code for %s, %s", result.name, result.err)
+			failures = append(failures, result)
 		} else {
-			log.Printf("Code generated for table %s, into package %s/%s.go", result.name, config.packageName, result.name)
+			log.Printf("Code generated for table %s, into package %s/%s", result.name, config.packageName, config.fileName(result.name))
 		}
 	}
 	close(jobs)
+	sort.Slice(failures, func(i, j int) bool { return failures[i].name < failures[j].name })
+	errs := make([]error, len(failures))
+	for i, result := range failures {
+		errs[i] = fmt.Errorf("Fail to generate the code for the table %s, %s", result.name, result.err)
+	}
+	return errors.Join(errs...)
+}
+
+// buildModelFields makes the fields of the table with the go types, enums, nullable types and tags, the fields
+// of all the tables are built before generating, so the relations have the same fields of the other tables.
+func (cc CodeConfig) buildModelFields(tName string, schema drivers.TableSchema, enums map[string]ModelEnum) ([]ModelField, error) {
+	fields, err := cc.newModelFields(schema)
+	if err != nil {
+		return nil, err
+	}
+	for i, col := range schema {
+		field := &fields[i]
+		if field.Name != cc.goName(col.ColumnName) {
+			log.Printf("[%s] The column %s is named as %s", tName, col.ColumnName, field.Name)
+		}
+		field.setDecimal(cc.decimal, col)
+		if jsonType, ok := cc.jsonTypes[tName+"."+col.ColumnName]; ok {
+			if err := field.setJsonType(jsonType); err != nil {
+				log.Printf("Skip the json type %s, %s", jsonType, err)
+			}
+		}
+		if goType, ok := cc.columnTypes[tName+"."+col.ColumnName]; ok {
+			field.setGoType(goType)
+		} else if enum, ok := enums[tName+"."+col.ColumnName]; ok {
+			field.Enum = &enum
+			field.Type = enum.Name
+		}
+		field.setNullable(cc.nullable)
+		field.IsSensitive = cc.isSensitive(tName, col)
+		field.Tags = cc.fieldTags(*field, col)
+		if tags, ok := cc.columnTags[tName+"."+col.ColumnName]; ok {
+			if err := field.addTags(tags); err != nil {
+				log.Printf("Skip the tags %s, %s", tags, err)
+			}
+		}
+	}
+	return fields, nil
 }
 
-func generateModel(dbName, tName string, schema drivers.TableSchema, config CodeConfig, tmpl *template.Template) error {
-	file, err := os.Create(path.Join(config.packageName, tName+".go"))
+func generateModel(dbName, tName string, schema drivers.TableSchema, dbSchema drivers.DbSchema, modelFields map[string][]ModelField, config CodeConfig, tmpl *template.Template) error {
+	fields := modelFields[tName]
+	file, err := os.Create(path.Join(config.packageName, config.fileName(tName)))
 	if err != nil {
 		return err
 	}
@@ -101,7 +371,7 @@ func generateModel(dbName, tName string, schema drivers.TableSchema, config Code
 	}()
 
 	model := ModelMeta{
-		Name:      toCapitalCase(tName),
+		Name:      config.modelName(tName),
 		DbName:    dbName,
 		TableName: tName,
 		Fields:    make([]ModelField, len(schema)),
@@ -111,22 +381,17 @@ func generateModel(dbName, tName string, schema drivers.TableSchema, config Code
 	}
 	needTime := false
 	needFmt := false
-	for i, col := range schema {
-		field := ModelField{
-			Name:            toCapitalCase(col.ColumnName),
-			ColumnName:      col.ColumnName,
-			Type:            col.DataType,
-			IsNullable:      strings.ToUpper(col.IsNullable) == "YES",
-			JsonMeta:        fmt.Sprintf("`json:\"%s\"`", col.ColumnName),
-			IsPrimaryKey:    strings.ToUpper(col.ColumnKey) == "PRI",
-			IsUniqueKey:     strings.ToUpper(col.ColumnKey) == "UNI",
-			IsIndexed:       strings.ToUpper(col.ColumnKey) == "MUL",
-			IsAutoIncrement: strings.ToUpper(col.Extra) == "AUTO_INCREMENT",
-			DefaultValue:    col.DefaultValue,
-			Extra:           col.Extra,
-			Comment:         col.Comment,
-		}
-		if field.Type == "time.Time" {
+	for i := range schema {
+		field := fields[i]
+		// the shared enum type is generated once with the first table using it
+		if field.Enum != nil && field.Enum.table == tName && !model.hasEnum(field.Enum.Name) {
+			model.Enums = append(model.Enums, *field.Enum)
+			needFmt = true
+		}
+		if config.strict && field.ParseFrom("rb") != "" {
+			needFmt = true
+		}
+		if strings.HasPrefix(field.Type, "time.") {
 			needTime = true
 		}
 		if field.IsPrimaryKey {
@@ -142,10 +407,16 @@ func generateModel(dbName, tName string, schema drivers.TableSchema, config Code
 			model.Indexed = append(model.Indexed, field)
 		}
 
+		if field.ForeignKey != nil {
+			model.ForeignKeys = append(model.ForeignKeys, field)
+		}
+
 		model.Fields[i] = field
 	}
+	model.Indexes = buildIndexes(model, schema)
+	model.BelongsTo, model.HasMany = buildRelations(model, dbSchema, modelFields)
 
-	if err := model.GenHeader(w, tmpl, needTime, needFmt); err != nil {
+	if err := model.GenHeader(w, tmpl, needTime, needFmt, model.HasStringEnums()); err != nil {
 		return fmt.Errorf("[%s] Fail to gen model header, %s", tName, err)
 	}
 	if err := model.GenStruct(w, tmpl); err != nil {
@@ -164,11 +435,31 @@ func generateModel(dbName, tName string, schema drivers.TableSchema, config Code
 	return nil
 }
 
+func newModelField(col drivers.Column) ModelField {
+	return ModelField{
+		Name:            toCapitalCase(col.ColumnName),
+		ColumnName:      col.ColumnName,
+		Type:            col.DataType,
+		ColumnType:      col.ColumnType,
+		IsNullable:      strings.ToUpper(col.IsNullable) == "YES",
+		Tags:            []ModelTag{ModelTag{"json", col.ColumnName}},
+		IsPrimaryKey:    strings.ToUpper(col.ColumnKey) == "PRI",
+		IsUniqueKey:     strings.ToUpper(col.ColumnKey) == "UNI",
+		IsIndexed:       strings.ToUpper(col.ColumnKey) == "MUL",
+		IsAutoIncrement: strings.ToUpper(col.Extra) == "AUTO_INCREMENT",
+		DefaultValue:    col.DefaultValue,
+		Extra:           col.Extra,
+		Comment:         col.Comment,
+		ForeignKey:      col.ForeignKey,
+	}
+}
+
 type ModelField struct {
 	Name            string
 	ColumnName      string
 	Type            string
-	JsonMeta        string
+	ColumnType      string
+	Tags            []ModelTag
 	IsNullable      bool
 	IsPrimaryKey    bool
 	IsUniqueKey     bool
@@ -177,24 +468,497 @@ type ModelField struct {
 	DefaultValue    string
 	Extra           string
 	Comment         string
+	ForeignKey      *drivers.ForeignKey
+	Enum            *ModelEnum
+	NullType        string
+	Precision       int
+	Scale           int
+	Import          string
+	Converter       string
+	ConverterImport string
+	IsJsonType      bool
+	IsSensitive     bool
+	nullable        string
+}
+
+// ModelTag is the key and value of the struct tag, e.g. json:"user_id,omitempty"
+type ModelTag struct {
+	Key   string
+	Value string
+}
+
+// JsonMeta is the struct tag of the field including the backquotes, e.g. `json:"user_id" db:"user_id"`
+func (f ModelField) JsonMeta() string {
+	if len(f.Tags) == 0 {
+		return ""
+	}
+	tags := make([]string, len(f.Tags))
+	for i, tag := range f.Tags {
+		tags[i] = fmt.Sprintf("%s:%s", tag.Key, strconv.Quote(tag.Value))
+	}
+	return "`" + strings.Join(tags, " ") + "`"
+}
+
+// Tag returns the value of the struct tag by the key for the templates, e.g. {{.Tag "json"}}
+func (f ModelField) Tag(key string) string {
+	for _, tag := range f.Tags {
+		if tag.Key == key {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
+// addTags merges the struct tags like `json:"-" validate:"email"`, the ones with the same keys are replaced
+// and the others are appended.
+func (f *ModelField) addTags(tags string) error {
+	parsed, err := parseStructTags(strings.Trim(strings.TrimSpace(tags), "`"))
+	if err != nil {
+		return err
+	}
+	for _, tag := range parsed {
+		replaced := false
+		for i := range f.Tags {
+			if f.Tags[i].Key == tag.Key {
+				f.Tags[i].Value, replaced = tag.Value, true
+			}
+		}
+		if !replaced {
+			f.Tags = append(f.Tags, tag)
+		}
+	}
+	return nil
+}
+
+// parseStructTags parses the tags in the conventional format of the reflect.StructTag, e.g. json:"id" db:"id"
+func parseStructTags(tags string) ([]ModelTag, error) {
+	parsed := make([]ModelTag, 0, 2)
+	for tags = strings.TrimSpace(tags); tags != ""; tags = strings.TrimSpace(tags) {
+		pos := strings.Index(tags, ":\"")
+		if pos <= 0 || strings.ContainsAny(tags[:pos], " \t\"") {
+			return nil, fmt.Errorf("Invalid struct tag %q", tags)
+		}
+		end := pos + 2
+		for end < len(tags) && tags[end] != '"' {
+			if tags[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(tags) {
+			return nil, fmt.Errorf("Invalid struct tag %q, the value is not quoted", tags)
+		}
+		value, err := strconv.Unquote(tags[pos+1 : end+1])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid struct tag %q, %s", tags, err)
+		}
+		parsed = append(parsed, ModelTag{tags[:pos], value})
+		tags = tags[end+1:]
+	}
+	return parsed, nil
+}
+
+// fieldTags makes the struct tags by -tags, the db tag is always the column name and the others are the column
+// name or the lower camel case of the field name by -tag-case, e.g. json:"userId,omitempty" with -omitempty.
+// The validate:"max=50" is added for the string columns with the length by -validate.
+// The sensitive fields are json:"-" and "-" for the others except db, so they are not in the String() or json.
+func (cc CodeConfig) fieldTags(field ModelField, col drivers.Column) []ModelTag {
+	keys := cc.tags
+	if len(keys) == 0 {
+		keys = []string{"json"}
+	}
+	tags := make([]ModelTag, 0, len(keys)+2)
+	hasJson := false
+	for _, key := range keys {
+		value := col.ColumnName
+		if key == "json" {
+			hasJson = true
+		}
+		if key != "db" && field.IsSensitive {
+			value = "-"
+		} else if key != "db" {
+			if cc.tagCase == "camel" {
+				value = lowerCamel(field.Name)
+			}
+			if cc.omitempty {
+				value += ",omitempty"
+			}
+		}
+		tags = append(tags, ModelTag{key, value})
+	}
+	if field.IsSensitive && !hasJson {
+		tags = append(tags, ModelTag{"json", "-"})
+	}
+	if cc.validate && col.DataType == "string" && col.CharMaxLength > 0 {
+		tags = append(tags, ModelTag{"validate", fmt.Sprintf("max=%d", col.CharMaxLength)})
+	}
+	return tags
+}
+
+// isSensitive checks the column by the -sensitive "table.column" or the marker "@sensitive" in the comment
+func (cc CodeConfig) isSensitive(tName string, col drivers.Column) bool {
+	if cc.sensitive[tName+"."+col.ColumnName] {
+		return true
+	}
+	for _, word := range strings.Fields(strings.ToLower(col.Comment)) {
+		if strings.Trim(word, ",;.()[]") == "@sensitive" {
+			return true
+		}
+	}
+	return false
+}
+
+// setDecimal changes the DECIMAL/NUMERIC column from float64 into the exact decimal type, which is gmq.Decimal
+// by "gmq" or the user type like "github.com/shopspring/decimal.Decimal" which should be a sql.Scanner.
+func (f *ModelField) setDecimal(mode string, col drivers.Column) {
+	if mode == "" || !col.IsDecimal() || f.Type != "float64" {
+		return
+	}
+	f.Precision, f.Scale = col.NumericPrecision, col.NumericScale
+	if mode == "gmq" {
+		f.Type = "gmq.Decimal"
+		return
+	}
+	f.Type, f.Import = parseGoType(mode)
+	f.Converter = fmt.Sprintf("gmq.AsScanner[%s]", f.Type)
+}
+
+// setJsonType changes the json column into the user type, e.g. "github.com/me/myapp.ArticleMeta", which would be
+// unmarshaled from and marshaled into the json. The NULL values could be the pointer types like "*myapp.ArticleMeta".
+func (f *ModelField) setJsonType(jsonType string) error {
+	if f.Type != "json.RawMessage" {
+		return fmt.Errorf("Column %s is not a json column, but %s", f.ColumnName, f.ColumnType)
+	}
+	f.Type, f.Import = parseGoType(jsonType)
+	f.Converter = fmt.Sprintf("gmq.AsJsonOf[%s]", f.Type)
+	f.IsJsonType = true
+	return nil
+}
+
+// setGoType overrides the type of the column like "net/mail.Address:github.com/me/myconv.AsAddress", the converter
+// is a func(sql.RawBytes) T, or the type should be a sql.Scanner if there is no converter.
+func (f *ModelField) setGoType(goType string) {
+	converter := ""
+	if pos := strings.LastIndex(goType, ":"); pos >= 0 {
+		goType, converter = goType[:pos], goType[pos+1:]
+	}
+	f.Type, f.Import = parseGoType(goType)
+	f.Converter = ""
+	if _, ok := kConverters[f.Type]; ok {
+		// the builtin types like time.Time are imported already
+		f.Import = ""
+	} else if converter == "" {
+		f.Converter = fmt.Sprintf("gmq.AsScanner[%s]", f.Type)
+	}
+	if converter != "" {
+		f.Converter, f.ConverterImport = parseGoType(converter)
+	}
+}
+
+// parseColumnTypes parses the column types from the flags like "article.meta=github.com/me/myapp.ArticleMeta,user.profile=*myapp.Profile"
+func parseColumnTypes(value string) (map[string]string, error) {
+	columnTypes := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || !strings.Contains(parts[0], ".") || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("Invalid column type %q, it should be like table.column=type", pair)
+		}
+		columnTypes[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return columnTypes, nil
+}
+
+// parseSensitive parses the sensitive columns from the flag like "user.password,user.api_token"
+func parseSensitive(value string) (map[string]bool, error) {
+	sensitive := make(map[string]bool)
+	for _, column := range splitList(value) {
+		if parts := strings.SplitN(column, ".", 2); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Invalid sensitive column %q, it should be like table.column", column)
+		}
+		sensitive[column] = true
+	}
+	return sensitive, nil
+}
+
+// splitList splits the comma separated values and drops the empty ones
+func splitList(value string) []string {
+	values := make([]string, 0, 2)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseNames parses the renames like "article=Post,user.user_id=AuthorID" into the struct names of the tables
+// and the field names of the "table.column"
+func parseNames(value string) (structNames, fieldNames map[string]string, err error) {
+	structNames, fieldNames = make(map[string]string), make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, nil, fmt.Errorf("Invalid name %q, it should be like table=Struct or table.column=Field", pair)
+		}
+		if key := strings.TrimSpace(parts[0]); strings.Contains(key, ".") {
+			fieldNames[key] = strings.TrimSpace(parts[1])
+		} else {
+			structNames[key] = strings.TrimSpace(parts[1])
+		}
+	}
+	return structNames, fieldNames, nil
+}
+
+// parseGoType gets the type name and the import path from the type like "*github.com/me/myapp.ArticleMeta",
+// the package name should be the last element of the import path, and the types without the package
+// are in the same package with the models.
+func parseGoType(goType string) (typeName, importPath string) {
+	name := strings.TrimLeft(goType, "*[]")
+	prefix := goType[:len(goType)-len(name)]
+	pos := strings.LastIndex(name, ".")
+	if pos < 0 {
+		return goType, ""
+	}
+	return prefix + name[strings.LastIndex(name, "/")+1:], name[:pos]
+}
+
+var kSqlNullTypes = map[string][2]string{
+	"string":    {"sql.NullString", "String"},
+	"int64":     {"sql.NullInt64", "Int64"},
+	"float64":   {"sql.NullFloat64", "Float64"},
+	"bool":      {"sql.NullBool", "Bool"},
+	"time.Time": {"sql.NullTime", "Time"},
+}
+
+// setNullable wraps the type of the nullable column by the mode, "sql" for the sql.NullXxx or sql.Null[T],
+// "pointer" for *T and "option" for the gmq.Option[T]. The slices are not wrapped since they could be nil already.
+func (f *ModelField) setNullable(mode string) {
+	if !f.IsNullable || f.IsPrimaryKey || mode == "" || strings.HasPrefix(f.Type, "[]") || strings.HasPrefix(f.Type, "*") ||
+		f.Type == "json.RawMessage" || f.IsJsonType {
+		return
+	}
+	f.nullable = mode
+	switch mode {
+	case "option":
+		f.NullType = fmt.Sprintf("gmq.Option[%s]", f.Type)
+	case "pointer":
+		f.NullType = "*" + f.Type
+	case "sql":
+		if nullType, ok := kSqlNullTypes[f.Type]; ok {
+			f.NullType = nullType[0]
+		} else {
+			f.NullType = fmt.Sprintf("sql.Null[%s]", f.Type)
+		}
+	}
+}
+
+// FieldType is the type in the struct, which could be the wrapped type for the nullable column
+func (f ModelField) FieldType() string {
+	if f.NullType != "" {
+		return f.NullType
+	}
+	return f.Type
+}
+
+// NullCheck is the expression to tell if the field of the object is NULL, e.g. obj.Age == nil
+func (f ModelField) NullCheck(obj string) string {
+	switch f.nullable {
+	case "pointer":
+		return fmt.Sprintf("%s.%s == nil", obj, f.Name)
+	case "sql":
+		return fmt.Sprintf("!%s.%s.Valid", obj, f.Name)
+	case "option":
+		return fmt.Sprintf("!%s.%s.IsDefined()", obj, f.Name)
+	}
+	return "false"
+}
+
+// ValueOf is the expression of the unwrapped field value, e.g. *obj.Age or obj.Age.Int64
+func (f ModelField) ValueOf(obj string) string {
+	switch f.nullable {
+	case "pointer":
+		return fmt.Sprintf("*%s.%s", obj, f.Name)
+	case "sql":
+		if nullType, ok := kSqlNullTypes[f.Type]; ok {
+			return fmt.Sprintf("%s.%s.%s", obj, f.Name, nullType[1])
+		}
+		return fmt.Sprintf("%s.%s.V", obj, f.Name)
+	case "option":
+		return fmt.Sprintf("gmq.OptionValue(%s.%s.Get())", obj, f.Name)
+	}
+	return fmt.Sprintf("%s.%s", obj, f.Name)
+}
+
+// ConvertFrom is the expression to convert the RawBytes into the field value, e.g. gmq.AsInt(rb[i]),
+// the nil RawBytes would be the NULL for the wrapped types.
+func (f ModelField) ConvertFrom(rb string) string {
+	converter, args := f.converterCall(rb)
+	return f.WrapValue(fmt.Sprintf("%s(%s)", converter, args), rb)
+}
+
+// converterCall gives the converter and the args, the time values would be in the location of the dbtx
+func (f ModelField) converterCall(rb string) (string, string) {
+	if f.Converter != "" {
+		return f.Converter, rb
+	}
+	converter := f.baseField().ConverterFuncName()
+	switch {
+	case converter == "AsTime":
+		return "gmq.AsTimeIn", rb + ", gmq.LocationOf(dbtx)"
+	case f.Type == "gmq.Decimal":
+		return "gmq.AsDecimal", fmt.Sprintf("%s, %d, %d", rb, f.Precision, f.Scale)
+	}
+	return "gmq." + converter, rb
+}
+
+// ParseFrom is the expression for the strict mode to convert the RawBytes with the error, e.g. gmq.ParseInt(rb[i]),
+// it would be empty if the conversion never fails or it is a custom converter.
+func (f ModelField) ParseFrom(rb string) string {
+	converter, args := f.converterCall(rb)
+	// the generic converters of gmq are all with the ParseXxx ones
+	if !kParseConverters[converter] && !(strings.HasPrefix(converter, "gmq.As") && strings.Contains(converter, "[")) {
+		return ""
+	}
+	return fmt.Sprintf("gmq.Parse%s(%s)", strings.TrimPrefix(converter, "gmq.As"), args)
+}
+
+var kParseConverters = map[string]bool{
+	"gmq.AsBool":         true,
+	"gmq.AsInt":          true,
+	"gmq.AsInt64":        true,
+	"gmq.AsUint32":       true,
+	"gmq.AsUint64":       true,
+	"gmq.AsFloat32":      true,
+	"gmq.AsFloat64":      true,
+	"gmq.AsTimeIn":       true,
+	"gmq.AsDecimal":      true,
+	"gmq.AsDuration":     true,
+	"gmq.AsIntArray":     true,
+	"gmq.AsInt64Array":   true,
+	"gmq.AsFloat64Array": true,
+	"gmq.AsBoolArray":    true,
+}
+
+// baseField is the field of the underlying type of the enum for the converters
+func (f ModelField) baseField() ModelField {
+	if f.Enum != nil {
+		return ModelField{Type: f.Enum.Type, ColumnType: f.ColumnType}
+	}
+	return f
+}
+
+// WrapValue turns the converted value into the field value, as the enum type or the wrapped nullable type
+func (f ModelField) WrapValue(value, rb string) string {
+	if f.Enum != nil {
+		value = fmt.Sprintf("%s(%s)", f.Type, value)
+	}
+	switch f.nullable {
+	case "pointer":
+		return fmt.Sprintf("gmq.Ptr(%s, %s != nil)", value, rb)
+	case "sql":
+		if nullType, ok := kSqlNullTypes[f.Type]; ok {
+			return fmt.Sprintf("%s{%s: %s, Valid: %s != nil}", nullType[0], nullType[1], value, rb)
+		}
+		return fmt.Sprintf("%s{V: %s, Valid: %s != nil}", f.NullType, value, rb)
+	case "option":
+		return fmt.Sprintf("gmq.NewOption(%s, %s != nil)", value, rb)
+	}
+	return value
+}
+
+func (f ModelField) IsInteger() bool {
+	switch f.Type {
+	case "int", "int64", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+var kConverters = map[string]string{
+	"int64":           "AsInt64",
+	"int":             "AsInt",
+	"string":          "AsString",
+	"time.Time":       "AsTime",
+	"float64":         "AsFloat64",
+	"bool":            "AsBool",
+	"[]byte":          "AsByteArray",
+	"float32":         "AsFloat32",
+	"json.RawMessage": "AsJson",
+	"[]string":        "AsStringArray",
+	"[]int":           "AsIntArray",
+	"[]int64":         "AsInt64Array",
+	"[]float64":       "AsFloat64Array",
+	"[]bool":          "AsBoolArray",
+	"uint32":          "AsUint32",
+	"uint64":          "AsUint64",
+	"time.Duration":   "AsDuration",
 }
 
 func (f ModelField) ConverterFuncName() string {
-	convertors := map[string]string{
-		"int64":     "AsInt64",
-		"int":       "AsInt",
-		"string":    "AsString",
-		"time.Time": "AsTime",
-		"float64":   "AsFloat64",
-		"bool":      "AsBool",
-                "[]byte":    "AsByteArray",
-	}
-	if c, ok := convertors[f.Type]; ok {
+	// the MySQL BIT and SET columns are not in the same format with the other columns of the same go type
+	columnType := strings.ToLower(f.ColumnType)
+	if f.Type == "uint64" && strings.HasPrefix(columnType, "bit") {
+		return "AsBit"
+	}
+	if f.Type == "[]string" && strings.HasPrefix(columnType, "set") {
+		return "AsSet"
+	}
+	if c, ok := kConverters[f.Type]; ok {
 		return c
 	}
 	return "AsString"
 }
 
+// ParamName is the lower camel case of the field name for the func params, e.g. UserId => userId, ID => id,
+// which would get a "Value" suffix if it is a Go keyword or the name used in the generated funcs.
+func (f ModelField) ParamName() string {
+	name := lowerCamel(f.Name)
+	if token.Lookup(name).IsKeyword() || name == "dbtx" || name == "filter" || name == "o" {
+		name += "Value"
+	}
+	return name
+}
+
+// lowerCamel lowers the leading upper case letters, e.g. UserId => userId, ID => id, URLPath => urlPath
+func lowerCamel(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	// keep the last upper case letter for the next word, e.g. URLPath => urlPath
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// IsComparable tells if the field could be a map key, the slices and json.RawMessage are not.
+func (f ModelField) IsComparable() bool {
+	// the decimals are the big numbers which cannot be the map keys
+	return !strings.HasPrefix(f.Type, "[]") && f.Type != "json.RawMessage" && f.Type != "gmq.Decimal" && f.Import == "" && !f.IsJsonType
+}
+
+// SqlValue is the expression of the value sent to the database, the user types of the json columns are marshaled
+func (f ModelField) SqlValue(v string) string {
+	if f.IsJsonType {
+		v = fmt.Sprintf("gmq.JsonValue(%s)", v)
+	}
+	if f.IsSensitive {
+		v = fmt.Sprintf("gmq.Sensitive(%s)", v)
+	}
+	return v
+}
+
 type PrimaryFields []*ModelField
 
 func (pf PrimaryFields) FormatObject() func(string) string {
@@ -252,9 +1016,531 @@ type ModelMeta struct {
 	Fields        []ModelField
 	Uniques       []ModelField
 	Indexed       []ModelField
+	Indexes       []ModelIndex
+	Enums         []ModelEnum
+	ForeignKeys   []ModelField
+	BelongsTo     []ModelRelation
+	HasMany       []ModelRelation
 	config        CodeConfig
 }
 
+// ModelEnum is the named type for the ENUM column, the values are the constants of the type,
+// e.g. ArticleStatus with ArticleStatusDraft = "draft", or the int type from the column comment
+// like "0: published, 1: draft" which would be ArticleState with ArticleStatePublished = 0.
+type ModelEnum struct {
+	Name   string
+	Type   string
+	Values []ModelEnumValue
+	table  string
+}
+
+// ModelEnumValue is the constant, the Literal is the value in the go source code, e.g. "draft" or 0
+type ModelEnumValue struct {
+	Name    string
+	Label   string
+	Literal string
+}
+
+func (e ModelEnum) IsString() bool {
+	return e.Type == "string"
+}
+
+// modelEnums names the enum types of the columns by "table.column", the ENUM columns of MySQL and the int columns
+// with the comments are named by the model and field, e.g. ArticleStatus, and the enum type of Postgres is shared
+// by the columns and named after the type, e.g. Mood for CREATE TYPE mood AS ENUM. The enum types and constants
+// would have the "Enum" suffix if the names are used by the models or the other enums, e.g. ArticleStatusEnum
+// for the table article_status, the tables and columns are in order so the names are always the same.
+func (cc CodeConfig) modelEnums(dbSchema drivers.DbSchema) (map[string]ModelEnum, error) {
+	tableNames := make([]string, 0, len(dbSchema))
+	for tName := range dbSchema {
+		tableNames = append(tableNames, tName)
+	}
+	sort.Strings(tableNames)
+	used := make(map[string]bool)
+	for _, tName := range tableNames {
+		for _, ident := range modelIdents(cc.modelName(tName)) {
+			used[ident] = true
+		}
+	}
+	isUsed := func(enum ModelEnum) bool {
+		if used[enum.Name] {
+			return true
+		}
+		for _, value := range enum.Values {
+			if used[value.Name] {
+				return true
+			}
+		}
+		return false
+	}
+
+	enums := make(map[string]ModelEnum)
+	shared := make(map[string]ModelEnum)
+	for _, tName := range tableNames {
+		schema := dbSchema[tName]
+		fields, err := cc.newModelFields(schema)
+		if err != nil {
+			return nil, err
+		}
+		for i, col := range schema {
+			key := tName + "." + col.ColumnName
+			if _, ok := cc.columnTypes[key]; ok {
+				continue
+			}
+			if enum, ok := shared[col.EnumType]; ok && col.EnumType != "" {
+				enums[key] = enum
+				continue
+			}
+			name := cc.modelName(tName) + fields[i].Name
+			var newEnum func(name string) ModelEnum
+			switch {
+			case col.EnumType != "":
+				name = exportedName(cc.goName(col.EnumType))
+				newEnum = func(name string) ModelEnum { return cc.newModelEnum(name, col.EnumValues) }
+			case len(col.EnumValues) > 0:
+				newEnum = func(name string) ModelEnum { return cc.newModelEnum(name, col.EnumValues) }
+			case cc.commentEnums && fields[i].IsInteger():
+				if values, labels, ok := parseCommentEnum(fields[i].Comment); ok {
+					newEnum = func(name string) ModelEnum {
+						return cc.newModelIntEnum(name, fields[i].Type, values, labels)
+					}
+				}
+			}
+			if newEnum == nil {
+				continue
+			}
+			enum := newEnum(name)
+			if isUsed(enum) {
+				if enum = newEnum(name + "Enum"); isUsed(enum) {
+					return nil, fmt.Errorf("The enum type %s of the column %s has the same name with the other types or constants in the generated code, please rename the table or column by -names", name, key)
+				}
+				log.Printf("[%s] The enum type of the column %s is named as %s", tName, col.ColumnName, enum.Name)
+			}
+			used[enum.Name] = true
+			for _, value := range enum.Values {
+				used[value.Name] = true
+			}
+			enum.table = tName
+			if col.EnumType != "" {
+				shared[col.EnumType] = enum
+			}
+			enums[key] = enum
+		}
+	}
+	return enums, nil
+}
+
+func (cc CodeConfig) newModelEnum(name string, values []string) ModelEnum {
+	return cc.newModelIntEnum(name, "string", values, values)
+}
+
+// newModelIntEnum makes the constant names from the labels, which would be the same as the values for a string enum.
+func (cc CodeConfig) newModelIntEnum(name string, baseType string, values []string, labels []string) ModelEnum {
+	enum := ModelEnum{Name: name, Type: baseType, Values: make([]ModelEnumValue, len(values))}
+	names := make(map[string]struct{})
+	for i, value := range values {
+		label := cc.goName(labels[i])
+		if label == "" {
+			label = "Empty"
+		}
+		constName := name + label
+		// the labels like "in-progress" and "in_progress" would be the same name
+		for n := 2; ; n++ {
+			if _, ok := names[constName]; !ok {
+				break
+			}
+			constName = fmt.Sprintf("%s%s%d", name, label, n)
+		}
+		names[constName] = struct{}{}
+		literal := value
+		if baseType == "string" {
+			literal = strconv.Quote(value)
+		}
+		enum.Values[i] = ModelEnumValue{constName, labels[i], literal}
+	}
+	return enum
+}
+
+// parseCommentEnum parses the column comment like "0: published, 1: draft, 2: hidden", the pairs could
+// be separated by commas, semicolons or new lines, and all of them have to be in the "value: label" form.
+func parseCommentEnum(comment string) (values []string, labels []string, ok bool) {
+	pairs := strings.FieldsFunc(comment, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	})
+	seen := make(map[int64]struct{})
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			return nil, nil, false
+		}
+		value, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+		label := strings.TrimSpace(parts[1])
+		if err != nil || label == "" {
+			return nil, nil, false
+		}
+		if _, dup := seen[value]; dup {
+			return nil, nil, false
+		}
+		seen[value] = struct{}{}
+		values = append(values, strconv.FormatInt(value, 10))
+		labels = append(labels, label)
+	}
+	return values, labels, len(values) > 0
+}
+
+// ModelIndex is an index of the table with the fields in the index order, the primary key is included.
+type ModelIndex struct {
+	Name      string
+	Fields    []ModelField
+	IsUnique  bool
+	IsPrimary bool
+}
+
+func (mi ModelIndex) IsComposite() bool {
+	return len(mi.Fields) > 1
+}
+
+// FinderName is GetByXxx for the unique index and FindByXxx for the others, e.g. GetByUserIdTitle
+func (mi ModelIndex) FinderName() string {
+	names := make([]string, len(mi.Fields))
+	for i, field := range mi.Fields {
+		names[i] = field.Name
+	}
+	if mi.IsUnique {
+		return "GetBy" + strings.Join(names, "")
+	}
+	return "FindBy" + strings.Join(names, "")
+}
+
+func (mi ModelIndex) FormatParams() string {
+	// userId int64, title string
+	params := make([]string, len(mi.Fields))
+	for i, field := range mi.Fields {
+		params[i] = fmt.Sprintf("%s %s", field.ParamName(), field.Type)
+	}
+	return strings.Join(params, ", ")
+}
+
+func (mi ModelIndex) FormatFilters(name string) string {
+	// filter := {{.Name}}Objs.Filter{{.Name}}("=", userId)
+	filters := make([]string, len(mi.Fields))
+	for i, field := range mi.Fields {
+		if i == 0 {
+			filters[i] = fmt.Sprintf("filter := %sObjs.Filter%s(\"=\", %s)", name, field.Name, field.ParamName())
+		} else {
+			filters[i] = fmt.Sprintf("filter = filter.And(%sObjs.Filter%s(\"=\", %s))", name, field.Name, field.ParamName())
+		}
+	}
+	return strings.Join(filters, "\n")
+}
+
+// buildIndexes maps the index columns to the model fields, the indexes with expressions are skipped.
+func buildIndexes(model ModelMeta, schema drivers.TableSchema) []ModelIndex {
+	indexes := make([]ModelIndex, 0)
+	for _, index := range schema.Indexes() {
+		mIndex := ModelIndex{
+			Name:      index.Name,
+			Fields:    make([]ModelField, 0, len(index.Columns)),
+			IsUnique:  index.IsUnique,
+			IsPrimary: index.IsPrimary,
+		}
+		for _, name := range index.Columns {
+			for _, field := range model.Fields {
+				if field.ColumnName == name {
+					mIndex.Fields = append(mIndex.Fields, field)
+					break
+				}
+			}
+		}
+		if len(mIndex.Fields) > 0 && len(mIndex.Fields) == len(index.Columns) {
+			indexes = append(indexes, mIndex)
+		}
+	}
+	return indexes
+}
+
+// ModelRelation is the navigation from the model to the RefModel through a single column foreign key,
+// e.g. Article.User() by the article.user_id and User.Articles() on the other side.
+type ModelRelation struct {
+	Name     string
+	Field    ModelField
+	RefModel string
+	RefField ModelField
+}
+
+// KeyType is the key type of the preload maps, which is the resolved type of the referenced field, e.g. the enum type
+func (r ModelRelation) KeyType() string {
+	return r.RefField.Type
+}
+
+// FieldValue is the local field value for the filter and the keys of the preloads, which has the same
+// type of the referenced field.
+func (r ModelRelation) FieldValue() string {
+	return r.Field.ValueOf("obj")
+}
+
+// buildRelations finds the foreign keys from the model to the other tables and the ones referencing the model,
+// only the single column foreign keys between the tables being generated are supported. The fields on both sides
+// are the ones of the models, and the relations are skipped if the go types are not the same, e.g. the enums.
+func buildRelations(model ModelMeta, dbSchema drivers.DbSchema, modelFields map[string][]ModelField) (belongsTo []ModelRelation, hasMany []ModelRelation) {
+	isSingleColumn := func(schema drivers.TableSchema, fKey *drivers.ForeignKey) bool {
+		count := 0
+		for _, col := range schema {
+			if col.ForeignKey != nil && col.ForeignKey.ConstraintName == fKey.ConstraintName {
+				count++
+			}
+		}
+		return count == 1
+	}
+	findField := func(tName string, columnName string) (ModelField, bool) {
+		for _, field := range modelFields[tName] {
+			if field.ColumnName == columnName {
+				return field, true
+			}
+		}
+		return ModelField{}, false
+	}
+	reserved := make(map[string]bool)
+	for name := range kModelMethods {
+		reserved[name] = true
+	}
+	for _, f := range model.Fields {
+		reserved[f.Name] = true
+	}
+	uniqueName := func(name string) string {
+		for reserved[name] {
+			name += "Obj"
+		}
+		reserved[name] = true
+		return name
+	}
+
+	for _, f := range model.ForeignKeys {
+		if _, ok := dbSchema[f.ForeignKey.RefTable]; !ok || !isSingleColumn(dbSchema[model.TableName], f.ForeignKey) {
+			continue
+		}
+		// the preloads need the keys to be comparable, and the referenced ones should not be NULL
+		refField, ok := findField(f.ForeignKey.RefTable, f.ForeignKey.RefColumn)
+		if !ok || !refField.IsComparable() || refField.NullType != "" {
+			continue
+		}
+		if f.Type != refField.Type {
+			log.Printf("[%s] Skip the relation of the column %s to %s.%s, the go types %s and %s are not the same",
+				model.TableName, f.ColumnName, f.ForeignKey.RefTable, f.ForeignKey.RefColumn, f.Type, refField.Type)
+			continue
+		}
+		name := exportedName(model.config.goName(relationBaseName(f.ColumnName, model.config.baseName(f.ForeignKey.RefTable))))
+		if name == "" {
+			name = model.config.modelName(f.ForeignKey.RefTable)
+		}
+		belongsTo = append(belongsTo, ModelRelation{
+			Name:     uniqueName(name),
+			Field:    f,
+			RefModel: model.config.modelName(f.ForeignKey.RefTable),
+			RefField: refField,
+		})
+	}
+
+	tableNames := make([]string, 0, len(dbSchema))
+	for tName := range dbSchema {
+		tableNames = append(tableNames, tName)
+	}
+	sort.Strings(tableNames)
+	for _, tName := range tableNames {
+		for _, col := range dbSchema[tName] {
+			if col.ForeignKey == nil || col.ForeignKey.RefTable != model.TableName || !isSingleColumn(dbSchema[tName], col.ForeignKey) {
+				continue
+			}
+			field, ok := findField(model.TableName, col.ForeignKey.RefColumn)
+			if !ok || !field.IsComparable() || field.NullType != "" {
+				continue
+			}
+			// the skipped one is logged by the other side
+			refField, ok := findField(tName, col.ColumnName)
+			if !ok || field.Type != refField.Type {
+				continue
+			}
+			// user.Articles() for article.user_id, or user.EditorArticles() for article.editor_id
+			name := model.config.pluralize(model.config.modelName(tName))
+			baseName := model.config.baseName(model.TableName)
+			if base := relationBaseName(col.ColumnName, baseName); base != baseName {
+				name = model.config.goName(base) + name
+			}
+			hasMany = append(hasMany, ModelRelation{
+				Name:     uniqueName(exportedName(name)),
+				Field:    field,
+				RefModel: model.config.modelName(tName),
+				RefField: refField,
+			})
+		}
+	}
+	return
+}
+
+// relationBaseName strips the "_id" suffix of the foreign key column, e.g. user_id => user, and uses
+// the referenced table name if nothing left.
+func relationBaseName(columnName, refTable string) string {
+	name := columnName
+	for _, suffix := range []string{"_id", "Id", "_ID"} {
+		if strings.HasSuffix(name, suffix) {
+			name = strings.TrimSuffix(name, suffix)
+			break
+		}
+	}
+	name = strings.TrimRight(name, "_")
+	if name == "" {
+		return refTable
+	}
+	return name
+}
+
+func pluralize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	case len(lower) > 1 && strings.HasSuffix(lower, "y") && !strings.ContainsAny(lower[len(lower)-2:len(lower)-1], "aeiou"):
+		return name[:len(name)-1] + "ies"
+	}
+	return name + "s"
+}
+
+// kIrregularPlurals are the plurals which cannot be singularized by the suffixes, the uncountable ones are kept
+var kIrregularPlurals = map[string]string{
+	"people": "person", "men": "man", "women": "woman", "children": "child", "teeth": "tooth", "feet": "foot",
+	"mice": "mouse", "geese": "goose", "oxen": "ox", "indices": "index", "matrices": "matrix", "vertices": "vertex",
+	"appendices": "appendix", "analyses": "analysis", "crises": "crisis", "theses": "thesis", "diagnoses": "diagnosis",
+	"criteria": "criterion", "phenomena": "phenomenon", "leaves": "leaf", "lives": "life", "knives": "knife",
+	"wives": "wife", "halves": "half", "shelves": "shelf", "wolves": "wolf", "heroes": "hero", "potatoes": "potato",
+	"tomatoes": "tomato", "echoes": "echo", "movies": "movie", "cookies": "cookie", "caches": "cache", "niches": "niche",
+	"statuses": "status", "buses": "bus", "bonuses": "bonus", "viruses": "virus", "campuses": "campus",
+	"aliases": "alias", "quizzes": "quiz",
+	"news": "news", "series": "series", "species": "species", "sheep": "sheep", "fish": "fish", "deer": "deer",
+	"data": "data", "metadata": "metadata", "media": "media", "information": "information", "equipment": "equipment",
+	"feedback": "feedback",
+}
+
+// newIrregulars adds the custom irregulars like "cacti=cactus,octopi=octopus" to the common ones
+func newIrregulars(custom string) (map[string]string, error) {
+	irregulars := make(map[string]string)
+	for plural, singular := range kIrregularPlurals {
+		irregulars[plural] = singular
+	}
+	for _, pair := range strings.Split(custom, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("Invalid irregular %q, it should be like plural=singular", pair)
+		}
+		irregulars[strings.ToLower(strings.TrimSpace(parts[0]))] = strings.ToLower(strings.TrimSpace(parts[1]))
+	}
+	return irregulars, nil
+}
+
+// singularize changes the last word of the name into the singular, e.g. blog_posts => blog_post,
+// categories => category and people => person by the irregulars.
+func singularize(name string, irregulars map[string]string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return name
+	}
+	last := words[len(words)-1]
+	pos := strings.LastIndex(name, last)
+	return name[:pos] + matchCase(singularWord(strings.ToLower(last), irregulars), last) + name[pos+len(last):]
+}
+
+func singularWord(word string, irregulars map[string]string) string {
+	if singular, ok := irregulars[word]; ok {
+		return singular
+	}
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zzes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "s") && len(word) > 1:
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// matchCase makes the lower case word in the same case of the original one, e.g. USERS => USER, Users => User
+func matchCase(word, original string) string {
+	if original == strings.ToUpper(original) {
+		return strings.ToUpper(word)
+	}
+	if first, _ := utf8.DecodeRuneInString(original); unicode.IsUpper(first) {
+		r, size := utf8.DecodeRuneInString(word)
+		return string(unicode.ToUpper(r)) + word[size:]
+	}
+	return word
+}
+
+// Finders are the indexes for the GetByXxx and FindByXxx funcs, the primary key is already there by Get.
+func (m ModelMeta) Finders() []ModelIndex {
+	finders := make([]ModelIndex, 0, len(m.Indexes))
+	names := make(map[string]struct{})
+	for _, index := range m.Indexes {
+		if index.IsPrimary {
+			continue
+		}
+		if _, ok := names[index.FinderName()]; !ok {
+			names[index.FinderName()] = struct{}{}
+			finders = append(finders, index)
+		}
+	}
+	return finders
+}
+
+func (m ModelMeta) hasEnum(name string) bool {
+	for _, enum := range m.Enums {
+		if enum.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m ModelMeta) HasStringEnums() bool {
+	for _, enum := range m.Enums {
+		if enum.IsString() {
+			return true
+		}
+	}
+	return false
+}
+
+// Imports are the packages of the user types for the fields
+func (m ModelMeta) Imports() []string {
+	imports := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, f := range m.Fields {
+		for _, path := range []string{f.Import, f.ConverterImport} {
+			if path != "" && !seen[path] {
+				seen[path] = true
+				imports = append(imports, path)
+			}
+		}
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// IsStrict tells if the conversion errors would be returned instead of the zero values
+func (m ModelMeta) IsStrict() bool {
+	return m.config.strict
+}
+
+func (m ModelMeta) HasRelations() bool {
+	return len(m.BelongsTo) > 0 || len(m.HasMany) > 0
+}
+
 func (m ModelMeta) HasAutoIncrementPrimaryKey() bool {
 	for _, pField := range m.PrimaryFields {
 		if pField.IsAutoIncrement {
@@ -343,13 +1629,16 @@ func (m ModelMeta) getTemplate(tmpl *template.Template, name string, defaultTmpl
 	return defaultTmpl
 }
 
-func (m ModelMeta) GenHeader(w *bufio.Writer, tmpl *template.Template, importTime, importFmt bool) error {
+func (m ModelMeta) GenHeader(w *bufio.Writer, tmpl *template.Template, importTime, importFmt, importDriver bool) error {
 	return m.getTemplate(tmpl, "header", tmHeader).Execute(w, map[string]interface{}{
-		"DbName":     m.DbName,
-		"TableName":  m.TableName,
-		"PkgName":    m.config.packageName,
-		"ImportTime": importTime,
-		"ImportFmt":  importFmt,
+		"DbName":       m.DbName,
+		"TableName":    m.TableName,
+		"FileName":     m.config.fileName(m.TableName),
+		"PkgName":      m.config.packageName,
+		"ImportTime":   importTime,
+		"ImportFmt":    importFmt,
+		"ImportDriver": importDriver,
+		"Imports":      m.Imports(),
 	})
 }
 
@@ -370,32 +1659,97 @@ func (m ModelMeta) GenManagedObjApi(w *bufio.Writer, tmpl *template.Template) er
 }
 
 func toCapitalCase(name string) string {
-	// cp___hello_12jiu -> CpHello12Jiu
-	data := []byte(name)
+	// cp___hello_12jiu -> CpHello12Jiu, and the non-ASCII letters are kept, e.g. café_名字 -> Café名字
+	runes := make([]rune, 0, len(name))
 	segStart := true
-	endPos := 0
-	for i := 0; i < len(data); i++ {
-		ch := data[i]
-		if (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') {
+	for _, ch := range name {
+		switch {
+		case unicode.IsLetter(ch):
 			if segStart {
-				if ch >= 'a' && ch <= 'z' {
-					ch = ch - 'a' + 'A'
-				}
+				ch = unicode.ToUpper(ch)
 				segStart = false
 			} else {
-				if ch >= 'A' && ch <= 'Z' {
-					ch = ch - 'A' + 'a'
-				}
+				ch = unicode.ToLower(ch)
 			}
-			data[endPos] = ch
-			endPos++
-		} else if ch >= '0' && ch <= '9' {
-			data[endPos] = ch
-			endPos++
+			runes = append(runes, ch)
+		case unicode.IsDigit(ch):
+			runes = append(runes, ch)
 			segStart = true
-		} else {
+		default:
 			segStart = true
 		}
 	}
-	return string(data[:endPos])
+	return string(runes)
+}
+
+// kCommonInitialisms are the ones of golint, which are kept in the upper case by the golint naming
+var kCommonInitialisms = []string{"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS",
+	"ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP",
+	"UI", "UID", "UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS"}
+
+// newInitialisms adds the custom initialisms like "SKU,VAT" to the common ones
+func newInitialisms(custom string) map[string]bool {
+	initialisms := make(map[string]bool)
+	for _, word := range kCommonInitialisms {
+		initialisms[word] = true
+	}
+	for _, word := range strings.Split(custom, ",") {
+		if word = strings.TrimSpace(word); word != "" {
+			initialisms[strings.ToUpper(word)] = true
+		}
+	}
+	return initialisms
+}
+
+func toGolintCase(name string, initialisms map[string]bool) string {
+	// http_api_key -> HTTPAPIKey, userId -> UserID, USER -> USER
+	if initialisms == nil {
+		initialisms = newInitialisms("")
+	}
+	words := splitWords(name)
+	for i, word := range words {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			words[i] = upper
+		} else {
+			first, size := utf8.DecodeRuneInString(word)
+			words[i] = string(unicode.ToUpper(first)) + word[size:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// splitWords splits the name by the non-alphanumeric chars, the digits and the camel case, e.g.
+// http_api_key => http api key, userID => user ID, HTTPServer2go => HTTP Server2 go
+func splitWords(name string) []string {
+	isLower := func(ch rune) bool { return unicode.IsLetter(ch) && !unicode.IsUpper(ch) }
+	runes := []rune(name)
+	words := make([]string, 0, 4)
+	word := make([]rune, 0, len(runes))
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+	for i, ch := range runes {
+		switch {
+		case unicode.IsUpper(ch):
+			// the last upper case of an initialism starts the next word, e.g. HTTPServer
+			if len(word) > 0 && (!unicode.IsUpper(word[len(word)-1]) || (i+1 < len(runes) && isLower(runes[i+1]))) {
+				flush()
+			}
+			word = append(word, ch)
+		case isLower(ch):
+			if len(word) > 0 && unicode.IsDigit(word[len(word)-1]) {
+				flush()
+			}
+			word = append(word, ch)
+		case unicode.IsDigit(ch):
+			word = append(word, ch)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return words
 }
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// kConfigFiles are looked up in the current directory if the -config is not given
+var kConfigFiles = []string{"modelq.yaml", "modelq.yml", "modelq.json"}
+
+// Config is the modelq.yaml or modelq.json for the settings of the flags and the ones for the tables and columns,
+// the flags set in the command line would override the config file, e.g.
+//
+//	driver: mysql
+//	db: ${MYSQL_USER}:${MYSQL_PASSWORD}@tcp(127.0.0.1:3306)/blog
+//	schema: blog
+//	pkg: models
+//	tables:
+//	  include: [user, article]
+//	models:
+//	  article:
+//	    name: Post
+//	    columns:
+//	      user_id: {name: AuthorID}
+//	      meta: {json_type: github.com/me/myapp.ArticleMeta}
+//	      email: {type: net/mail.Address, tags: 'validate:"email"'}
+//	  user:
+//	    columns:
+//	      password: {sensitive: true}
+type Config struct {
+	Driver             string                 `json:"driver" yaml:"driver"`
+	Db                 string                 `json:"db" yaml:"db"`
+	Ddl                string                 `json:"ddl" yaml:"ddl"`
+	Schema             string                 `json:"schema" yaml:"schema"`
+	Pkg                string                 `json:"pkg" yaml:"pkg"`
+	Template           string                 `json:"template" yaml:"template"`
+	Tables             TablesConfig           `json:"tables" yaml:"tables"`
+	DontTouchTimestamp bool                   `json:"dont_touch_timestamp" yaml:"dont_touch_timestamp"`
+	CommentEnums       bool                   `json:"comment_enums" yaml:"comment_enums"`
+	Strict             bool                   `json:"strict" yaml:"strict"`
+	Nullable           string                 `json:"nullable" yaml:"nullable"`
+	Decimal            string                 `json:"decimal" yaml:"decimal"`
+	Naming             string                 `json:"naming" yaml:"naming"`
+	Initialisms        []string               `json:"initialisms" yaml:"initialisms"`
+	Singular           bool                   `json:"singular" yaml:"singular"`
+	Irregulars         map[string]string      `json:"irregulars" yaml:"irregulars"`
+	StripPrefixes      []string               `json:"strip_prefixes" yaml:"strip_prefixes"`
+	StripSuffixes      []string               `json:"strip_suffixes" yaml:"strip_suffixes"`
+	Tags               []string               `json:"tags" yaml:"tags"`
+	TagCase            string                 `json:"tag_case" yaml:"tag_case"`
+	Omitempty          bool                   `json:"omitempty" yaml:"omitempty"`
+	Validate           bool                   `json:"validate" yaml:"validate"`
+	Models             map[string]ModelConfig `json:"models" yaml:"models"`
+}
+
+// TablesConfig has the same rules with -tables, the names, globs like "billing_*" or regexps like "/^log_[0-9]+$/"
+type TablesConfig struct {
+	Include []string `json:"include" yaml:"include"`
+	Exclude []string `json:"exclude" yaml:"exclude"`
+}
+
+// ModelConfig is for the table, the Name is the struct name instead of the capital case of the table name
+type ModelConfig struct {
+	Name    string                  `json:"name" yaml:"name"`
+	Columns map[string]ColumnConfig `json:"columns" yaml:"columns"`
+}
+
+// ColumnConfig has the same values with -types, -json-types and -sensitive, the Name is the field name and the Tags
+// are merged into the struct tags of the field, e.g. json:"-" replaces the json one
+type ColumnConfig struct {
+	Name      string `json:"name" yaml:"name"`
+	Type      string `json:"type" yaml:"type"`
+	JsonType  string `json:"json_type" yaml:"json_type"`
+	Tags      string `json:"tags" yaml:"tags"`
+	Sensitive bool   `json:"sensitive" yaml:"sensitive"`
+}
+
+// findConfigFile returns the first of kConfigFiles in the directory, or empty if none
+func findConfigFile(dir string) string {
+	for _, name := range kConfigFiles {
+		file := filepath.Join(dir, name)
+		if fs, err := os.Stat(file); err == nil && !fs.IsDir() {
+			return file
+		}
+	}
+	return ""
+}
+
+// loadConfig reads the json file by the .json extension or the yaml one, the unknown keys are errors
+// to catch the typos, and the environment variables like ${DB_PASSWORD} are expanded in the connection settings.
+func loadConfig(file string) (Config, error) {
+	var config Config
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return config, err
+	}
+	if strings.ToLower(filepath.Ext(file)) == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	} else {
+		err = yaml.UnmarshalStrict(data, &config)
+	}
+	if err != nil {
+		return config, fmt.Errorf("Cannot parse the config file %s, %s", file, err)
+	}
+	config.Db = os.ExpandEnv(config.Db)
+	config.Ddl = os.ExpandEnv(config.Ddl)
+	config.Schema = os.ExpandEnv(config.Schema)
+	return config, nil
+}
+
+// mergeNames adds the struct names of the tables and the field names of the "table.column" into the maps,
+// the ones already in the maps are from the command line and kept.
+func (c Config) mergeNames(structNames, fieldNames map[string]string) {
+	for table, model := range c.Models {
+		if _, ok := structNames[table]; !ok && model.Name != "" {
+			structNames[table] = model.Name
+		}
+		for column, settings := range model.Columns {
+			if _, ok := fieldNames[table+"."+column]; !ok && settings.Name != "" {
+				fieldNames[table+"."+column] = settings.Name
+			}
+		}
+	}
+}
+
+// mergeColumns adds the column settings of the config into the "table.column" maps of the -types, -json-types
+// and the tags, the ones already in the maps are from the command line and kept.
+func (c Config) mergeColumns(columnTypes, jsonTypes, columnTags map[string]string) {
+	for table, model := range c.Models {
+		for column, settings := range model.Columns {
+			key := table + "." + column
+			for _, kv := range []struct {
+				values map[string]string
+				value  string
+			}{{columnTypes, settings.Type}, {jsonTypes, settings.JsonType}, {columnTags, settings.Tags}} {
+				if _, ok := kv.values[key]; !ok && kv.value != "" {
+					kv.values[key] = kv.value
+				}
+			}
+		}
+	}
+}
+
+// mergeSensitive adds the sensitive columns of the config into the "table.column" set of the -sensitive
+func (c Config) mergeSensitive(sensitive map[string]bool) {
+	for table, model := range c.Models {
+		for column, settings := range model.Columns {
+			if settings.Sensitive {
+				sensitive[table+"."+column] = true
+			}
+		}
+	}
+}
+
+// flagValues returns the values of the settings for the flags, the empty ones are not set
+func (c Config) flagValues() map[string]string {
+	boolValue := func(b bool) string {
+		if b {
+			return "true"
+		}
+		return ""
+	}
+	irregulars := make([]string, 0, len(c.Irregulars))
+	for plural, singular := range c.Irregulars {
+		irregulars = append(irregulars, plural+"="+singular)
+	}
+	sort.Strings(irregulars)
+	tables := append([]string{}, c.Tables.Include...)
+	for _, tName := range c.Tables.Exclude {
+		tables = append(tables, "!"+tName)
+	}
+	return map[string]string{
+		"driver":               c.Driver,
+		"db":                   c.Db,
+		"ddl":                  c.Ddl,
+		"schema":               c.Schema,
+		"pkg":                  c.Pkg,
+		"template":             c.Template,
+		"tables":               strings.Join(tables, ","),
+		"dont-touch-timestamp": boolValue(c.DontTouchTimestamp),
+		"comment-enums":        boolValue(c.CommentEnums),
+		"strict":               boolValue(c.Strict),
+		"nullable":             c.Nullable,
+		"decimal":              c.Decimal,
+		"naming":               c.Naming,
+		"initialisms":          strings.Join(c.Initialisms, ","),
+		"singular":             boolValue(c.Singular),
+		"irregulars":           strings.Join(irregulars, ","),
+		"strip-prefixes":       strings.Join(c.StripPrefixes, ","),
+		"strip-suffixes":       strings.Join(c.StripSuffixes, ","),
+		"tags":                 strings.Join(c.Tags, ","),
+		"tag-case":             c.TagCase,
+		"omitempty":            boolValue(c.Omitempty),
+		"validate":             boolValue(c.Validate),
+	}
+}
@@ -0,0 +1,1431 @@
+package drivers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DdlDriver loads the table schemas from the CREATE TABLE / CREATE INDEX / ALTER TABLE statements
+// in the sql files instead of a live database, the Dialect would be one of mysql, postgres and sqlite.
+type DdlDriver struct {
+	Dialect string
+}
+
+// LoadDatabaseSchema takes the dsnString as the ddl files separated by comma, a directory is also
+// accepted and all the *.sql files in it would be loaded by the name order, like the migrations.
+func (d DdlDriver) LoadDatabaseSchema(dsnString, schema, tableNames string) (DbSchema, error) {
+	log.Printf("[DDL Driver] Start to load tables schema from ddl files, %s, dialect=%s, tables=%s", dsnString, d.Dialect, tableNames)
+	files, err := d.ddlFiles(dsnString)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make(map[string]*ddlTable)
+	enums := make(map[string][]string)
+	order := make([]string, 0, 10)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, stmt := range splitDdlStatements(tokenizeDdl(string(data), d.Dialect)) {
+			p := &ddlParser{tokens: stmt, dialect: d.Dialect, tables: tables, enums: enums}
+			if err := p.parseStatement(); err != nil {
+				return nil, fmt.Errorf("[%s] %s", file, err)
+			}
+			order = append(order, p.created...)
+		}
+	}
+
+	tableFilter, err := NewTableFilter(tableNames)
+	if err != nil {
+		return nil, err
+	}
+
+	dbSchema := make(DbSchema)
+	for _, name := range order {
+		table, ok := tables[name]
+		if !ok {
+			continue
+		}
+		if !tableFilter.Match(name) {
+			continue
+		}
+		if schema != "" && table.schema != "" && table.schema != schema {
+			continue
+		}
+		dbSchema[name] = table.tableSchema(schema, d.Dialect, enums)
+	}
+	log.Printf("[DDL Driver] Loaded schema data of %d tables from ddl files", len(dbSchema))
+	return dbSchema, nil
+}
+
+func (d DdlDriver) ddlFiles(dsnString string) ([]string, error) {
+	files := make([]string, 0, 5)
+	for _, name := range strings.Split(dsnString, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		fs, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		if !fs.IsDir() {
+			files = append(files, name)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(name, "*.sql"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("No ddl files found in %q", dsnString)
+	}
+	return files, nil
+}
+
+// dataType maps the column type into the go type by the dialect driver, the type names in ddl
+// would be normalized into the ones which we get from the information_schema.
+func (d DdlDriver) dataType(typeName string, columnType string) string {
+	switch d.Dialect {
+	case "postgres":
+		if strings.HasSuffix(typeName, "[]") {
+			return PostgresDriver{}.dataType("_" + d.pqTypeName(strings.TrimSuffix(typeName, "[]")))
+		}
+		return PostgresDriver{}.dataType(d.pqTypeName(typeName))
+	case "sqlite":
+		return SqliteDriver{}.dataType(typeName)
+	}
+	if typeName == "serial" {
+		// SERIAL is an alias for BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE
+		columnType = "bigint unsigned"
+	}
+	return MysqlDriver{}.dataType(d.mysqlTypeName(typeName), columnType)
+}
+
+func (d DdlDriver) mysqlTypeName(typeName string) string {
+	aliases := map[string]string{
+		"bool":      "tinyint",
+		"boolean":   "tinyint",
+		"integer":   "int",
+		"dec":       "decimal",
+		"numeric":   "decimal",
+		"fixed":     "decimal",
+		"real":      "double",
+		"serial":    "bigint",
+		"character": "char",
+		"int1":      "tinyint",
+		"int2":      "smallint",
+		"int3":      "mediumint",
+		"middleint": "mediumint",
+		"int4":      "int",
+		"int8":      "bigint",
+	}
+	typeName = strings.Fields(typeName)[0]
+	if alias, ok := aliases[typeName]; ok {
+		return alias
+	}
+	return typeName
+}
+
+// pqTypeName normalizes the type name into the udt_name of postgres
+func (d DdlDriver) pqTypeName(typeName string) string {
+	aliases := map[string]string{
+		"integer":                     "int4",
+		"int":                         "int4",
+		"serial":                      "int4",
+		"serial4":                     "int4",
+		"bigint":                      "int8",
+		"bigserial":                   "int8",
+		"serial8":                     "int8",
+		"smallint":                    "int2",
+		"smallserial":                 "int2",
+		"serial2":                     "int2",
+		"boolean":                     "bool",
+		"decimal":                     "numeric",
+		"character varying":           "varchar",
+		"character":                   "bpchar",
+		"char":                        "bpchar",
+		"double precision":            "float8",
+		"float":                       "float8",
+		"real":                        "float4",
+		"timestamp without time zone": "timestamp",
+		"timestamp with time zone":    "timestamptz",
+		"time without time zone":      "time",
+		"time with time zone":         "timetz",
+	}
+	if alias, ok := aliases[typeName]; ok {
+		return alias
+	}
+	return typeName
+}
+
+type ddlIndex struct {
+	name      string
+	columns   []string
+	isUnique  bool
+	isPrimary bool
+}
+
+type ddlColumn struct {
+	name            string
+	columnType      string
+	dataType        string
+	defaultValue    string
+	isNullable      bool
+	isAutoIncrement bool
+	onUpdate        string
+	comment         string
+	foreignKey      *ForeignKey
+	enumValues      []string
+	enumType        string
+}
+
+type ddlTable struct {
+	schema  string
+	name    string
+	columns []*ddlColumn
+	indexes []ddlIndex
+}
+
+func (t *ddlTable) column(name string) (int, *ddlColumn) {
+	for i, col := range t.columns {
+		if strings.EqualFold(col.name, name) {
+			return i, col
+		}
+	}
+	return -1, nil
+}
+
+func (t *ddlTable) dropIndex(name string) bool {
+	for i, index := range t.indexes {
+		if strings.EqualFold(index.name, name) {
+			t.indexes = append(t.indexes[:i], t.indexes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (t *ddlTable) hasIndexPrefix(columns []string) bool {
+	for _, index := range t.indexes {
+		if len(index.columns) < len(columns) {
+			continue
+		}
+		matched := true
+		for i := range columns {
+			matched = matched && strings.EqualFold(index.columns[i], columns[i])
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaIndexes converts the indexes with the column names as they are defined in the table.
+func (t *ddlTable) schemaIndexes() []*Index {
+	indexes := make([]*Index, 0, len(t.indexes))
+	for _, index := range t.indexes {
+		columns := make([]string, len(index.columns))
+		for i, name := range index.columns {
+			columns[i] = name
+			if _, col := t.column(name); col != nil {
+				columns[i] = col.name
+			}
+		}
+		indexes = append(indexes, &Index{index.name, columns, index.isUnique, index.isPrimary})
+	}
+	return indexes
+}
+
+func (t *ddlTable) primaryKeys() []string {
+	for _, index := range t.indexes {
+		if index.isPrimary {
+			return index.columns
+		}
+	}
+	return nil
+}
+
+func (t *ddlTable) tableSchema(schema string, dialect string, enums map[string][]string) TableSchema {
+	if t.schema != "" {
+		schema = t.schema
+	}
+	indexes := t.schemaIndexes()
+	keys := indexColumnKeys(indexes)
+	pkCount := 0
+	for _, key := range keys {
+		if key == "PRI" {
+			pkCount++
+		}
+	}
+	tableSchema := make(TableSchema, 0, len(t.columns))
+	for _, col := range t.columns {
+		columnKey := keys[strings.ToLower(col.name)]
+		isNullable := "YES"
+		if !col.isNullable || columnKey == "PRI" {
+			isNullable = "NO"
+		}
+		extra := ""
+		// A single "INTEGER PRIMARY KEY" column is an alias for the ROWID in SQLite
+		isRowId := dialect == "sqlite" && columnKey == "PRI" && pkCount == 1 && col.columnType == "integer"
+		if col.isAutoIncrement || isRowId {
+			extra = "AUTO_INCREMENT"
+		} else if col.onUpdate != "" {
+			extra = "on update " + col.onUpdate
+		}
+		sCol := Column{
+			Schema:       schema,
+			TableName:    t.name,
+			ColumnName:   col.name,
+			DefaultValue: col.defaultValue,
+			DataType:     col.dataType,
+			ColumnType:   col.columnType,
+			ColumnKey:    columnKey,
+			Extra:        extra,
+			Comment:      col.comment,
+			IsNullable:   isNullable,
+			EnumValues:   col.enumValues,
+		}
+		if sCol.IsDecimal() {
+			sCol.NumericPrecision, sCol.NumericScale = parseNumericArgs(sCol.ColumnType)
+			if sCol.NumericPrecision == 0 && dialect == "mysql" {
+				// the default of MySQL is DECIMAL(10, 0)
+				sCol.NumericPrecision = 10
+			}
+		}
+		if sCol.DataType == "string" {
+			sCol.CharMaxLength, _ = parseNumericArgs(sCol.ColumnType)
+		}
+		if values, ok := enums[col.enumType]; ok && col.enumType != "" {
+			sCol.EnumValues, sCol.EnumType = append([]string{}, values...), col.enumType
+		}
+		if col.foreignKey != nil {
+			fKey := *col.foreignKey
+			if fKey.RefSchema == "" {
+				fKey.RefSchema = schema
+			}
+			sCol.ForeignKey = &fKey
+		}
+		tableSchema = append(tableSchema, sCol)
+	}
+	tableSchema.setIndexes(indexes)
+	return tableSchema
+}
+
+////// DDL tokenizer
+
+const (
+	tkIdent = iota
+	tkQuoted
+	tkString
+	tkNumber
+	tkPunct
+)
+
+type ddlToken struct {
+	kind int
+	text string
+}
+
+func (t ddlToken) is(word string) bool {
+	return t.kind == tkIdent && strings.EqualFold(t.text, word)
+}
+
+func (t ddlToken) isPunct(p string) bool {
+	return t.kind == tkPunct && t.text == p
+}
+
+func isIdentByte(ch byte) bool {
+	return ch == '_' || ch == '$' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch >= 0x80
+}
+
+func tokenizeDdl(sql string, dialect string) []ddlToken {
+	tokens := make([]ddlToken, 0, len(sql)/4)
+	for i := 0; i < len(sql); {
+		ch := sql[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == '-' && i+1 < len(sql) && sql[i+1] == '-', ch == '#' && dialect == "mysql":
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+		case ch == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 4
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			kind := tkQuoted
+			if ch == '\'' {
+				kind = tkString
+			}
+			var buf []byte
+			j := i + 1
+			for ; j < len(sql); j++ {
+				if sql[j] == '\\' && dialect == "mysql" && kind == tkString && j+1 < len(sql) {
+					j++
+					buf = append(buf, sql[j])
+				} else if sql[j] == ch {
+					if j+1 < len(sql) && sql[j+1] == ch {
+						buf = append(buf, ch)
+						j++
+					} else {
+						break
+					}
+				} else {
+					buf = append(buf, sql[j])
+				}
+			}
+			tokens = append(tokens, ddlToken{kind, string(buf)})
+			i = j + 1
+		case ch == '[' && dialect == "sqlite":
+			end := strings.IndexByte(sql[i:], ']')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			tokens = append(tokens, ddlToken{tkQuoted, sql[i+1 : i+end]})
+			i += end + 1
+		case ch == '$' && dialect == "postgres":
+			// dollar quoted string, e.g. $$ ... $$ or $body$ ... $body$
+			end := strings.IndexByte(sql[i+1:], '$')
+			if end < 0 {
+				i++
+				continue
+			}
+			tag := sql[i : i+end+2]
+			body := strings.Index(sql[i+len(tag):], tag)
+			if body < 0 {
+				body = len(sql) - i - len(tag)
+			}
+			tokens = append(tokens, ddlToken{tkString, sql[i+len(tag) : i+len(tag)+body]})
+			i += len(tag) + body + len(tag)
+		case ch >= '0' && ch <= '9', ch == '.' && i+1 < len(sql) && sql[i+1] >= '0' && sql[i+1] <= '9':
+			j := i
+			for j < len(sql) && ((sql[j] >= '0' && sql[j] <= '9') || sql[j] == '.' || sql[j] == 'e' || sql[j] == 'E') {
+				j++
+			}
+			tokens = append(tokens, ddlToken{tkNumber, sql[i:j]})
+			i = j
+		case isIdentByte(ch):
+			j := i
+			for j < len(sql) && isIdentByte(sql[j]) {
+				j++
+			}
+			tokens = append(tokens, ddlToken{tkIdent, sql[i:j]})
+			i = j
+		case ch == ':' && i+1 < len(sql) && sql[i+1] == ':':
+			tokens = append(tokens, ddlToken{tkPunct, "::"})
+			i += 2
+		default:
+			tokens = append(tokens, ddlToken{tkPunct, string(ch)})
+			i++
+		}
+	}
+	return tokens
+}
+
+func splitDdlStatements(tokens []ddlToken) [][]ddlToken {
+	stmts := make([][]ddlToken, 0, 10)
+	start := 0
+	for i, t := range tokens {
+		if t.isPunct(";") {
+			if i > start {
+				stmts = append(stmts, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		stmts = append(stmts, tokens[start:])
+	}
+	return stmts
+}
+
+////// DDL parser
+
+type ddlParser struct {
+	tokens  []ddlToken
+	pos     int
+	dialect string
+	tables  map[string]*ddlTable
+	enums   map[string][]string
+	created []string
+}
+
+func (p *ddlParser) eof() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *ddlParser) peek() ddlToken {
+	if p.eof() {
+		return ddlToken{kind: tkPunct}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *ddlParser) next() ddlToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// accept consumes the keywords only if all of them are matched in sequence.
+func (p *ddlParser) accept(words ...string) bool {
+	for i, w := range words {
+		if p.pos+i >= len(p.tokens) || !p.tokens[p.pos+i].is(w) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *ddlParser) acceptPunct(s string) bool {
+	if p.peek().isPunct(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *ddlParser) name() (string, error) {
+	t := p.next()
+	if t.kind != tkIdent && t.kind != tkQuoted {
+		return "", fmt.Errorf("Expect a name but got %q", t.text)
+	}
+	return t.text, nil
+}
+
+func (p *ddlParser) qualifiedName() (schema, name string, err error) {
+	if name, err = p.name(); err != nil {
+		return
+	}
+	for p.acceptPunct(".") {
+		schema = name
+		if name, err = p.name(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// group returns the tokens inside the parentheses which starts from current position.
+func (p *ddlParser) group() ([]ddlToken, error) {
+	if !p.acceptPunct("(") {
+		return nil, fmt.Errorf("Expect ( but got %q", p.peek().text)
+	}
+	start, depth := p.pos, 1
+	for ; !p.eof(); p.pos++ {
+		if p.tokens[p.pos].isPunct("(") {
+			depth++
+		} else if p.tokens[p.pos].isPunct(")") {
+			depth--
+			if depth == 0 {
+				p.pos++
+				return p.tokens[start : p.pos-1], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("Parentheses are not closed")
+}
+
+// splitItems splits the tokens by the commas which are not in any parentheses.
+func splitItems(tokens []ddlToken) [][]ddlToken {
+	items := make([][]ddlToken, 0, 5)
+	start, depth := 0, 0
+	for i, t := range tokens {
+		if t.isPunct("(") {
+			depth++
+		} else if t.isPunct(")") {
+			depth--
+		} else if t.isPunct(",") && depth == 0 {
+			items = append(items, tokens[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		items = append(items, tokens[start:])
+	}
+	return items
+}
+
+// nameList parses "(a, `b`(10) ASC, c DESC)" into column names, the expressions would be empty names.
+func (p *ddlParser) nameList() ([]string, error) {
+	group, err := p.group()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, 2)
+	for _, item := range splitItems(group) {
+		if len(item) == 0 || (item[0].kind != tkIdent && item[0].kind != tkQuoted) {
+			continue
+		}
+		if item[0].kind == tkIdent && len(item) > 1 && item[1].isPunct("(") && p.dialect != "mysql" {
+			// keep the place of the expression, so it would not be taken as a single column index
+			names = append(names, "")
+			continue
+		}
+		names = append(names, item[0].text)
+	}
+	return names, nil
+}
+
+func (p *ddlParser) table(name string) (*ddlTable, error) {
+	if table, ok := p.tables[name]; ok {
+		return table, nil
+	}
+	return nil, fmt.Errorf("Table %s is not defined", name)
+}
+
+func (p *ddlParser) parseStatement() error {
+	switch {
+	case p.accept("CREATE"):
+		p.accept("OR", "REPLACE")
+		for p.accept("TEMPORARY") || p.accept("TEMP") || p.accept("UNLOGGED") || p.accept("GLOBAL") || p.accept("LOCAL") {
+		}
+		if p.accept("TABLE") {
+			return p.parseCreateTable()
+		}
+		if p.accept("TYPE") {
+			return p.parseCreateType()
+		}
+		isUnique := p.accept("UNIQUE")
+		p.accept("FULLTEXT")
+		p.accept("SPATIAL")
+		if p.accept("INDEX") {
+			return p.parseCreateIndex(isUnique)
+		}
+	case p.accept("ALTER", "TABLE"):
+		return p.parseAlterTable()
+	case p.accept("RENAME", "TABLE"):
+		// RENAME TABLE a TO b, c TO d of MySQL
+		for !p.eof() {
+			_, oldName, err := p.qualifiedName()
+			if err != nil {
+				return err
+			}
+			if !p.accept("TO") {
+				return fmt.Errorf("Expect TO to rename the table %s", oldName)
+			}
+			_, newName, err := p.qualifiedName()
+			if err != nil {
+				return err
+			}
+			if table, ok := p.tables[oldName]; ok {
+				p.renameTable(table, newName)
+			}
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+	case p.accept("ALTER", "TYPE"):
+		return p.parseAlterType()
+	case p.accept("DROP", "TYPE"):
+		p.accept("IF", "EXISTS")
+		for !p.eof() {
+			_, name, err := p.qualifiedName()
+			if err != nil {
+				break
+			}
+			delete(p.enums, name)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+	case p.accept("DROP", "TABLE"):
+		p.accept("IF", "EXISTS")
+		for !p.eof() {
+			_, name, err := p.qualifiedName()
+			if err != nil {
+				break
+			}
+			delete(p.tables, name)
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+	case p.accept("DROP", "INDEX"):
+		p.accept("CONCURRENTLY")
+		p.accept("IF", "EXISTS")
+		_, name, err := p.qualifiedName()
+		if err != nil {
+			return nil
+		}
+		if p.accept("ON") {
+			if _, tName, err := p.qualifiedName(); err == nil {
+				if table, ok := p.tables[tName]; ok {
+					table.dropIndex(name)
+				}
+			}
+			return nil
+		}
+		for _, table := range p.tables {
+			if table.dropIndex(name) {
+				break
+			}
+		}
+	case p.accept("COMMENT", "ON", "COLUMN"):
+		return p.parseCommentOnColumn()
+	}
+	// All the other statements would be ignored
+	return nil
+}
+
+// parseCreateType only cares about the enum types of postgres, e.g. CREATE TYPE mood AS ENUM ('sad', 'ok')
+func (p *ddlParser) parseCreateType() error {
+	_, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	if !p.accept("AS", "ENUM") {
+		return nil
+	}
+	group, err := p.group()
+	if err != nil {
+		return err
+	}
+	p.enums[name] = stringLiterals(group)
+	return nil
+}
+
+func (p *ddlParser) parseAlterType() error {
+	_, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	values, ok := p.enums[name]
+	if !ok {
+		return nil
+	}
+	switch {
+	case p.accept("ADD", "VALUE"):
+		p.accept("IF", "NOT", "EXISTS")
+		value := p.next().text
+		for _, v := range values {
+			if v == value {
+				return nil
+			}
+		}
+		pos := len(values)
+		isBefore := p.accept("BEFORE")
+		if isBefore || p.accept("AFTER") {
+			neighbor := p.next().text
+			for i, v := range values {
+				if v == neighbor {
+					pos = i
+					if !isBefore {
+						pos++
+					}
+				}
+			}
+		}
+		values = append(values[:pos], append([]string{value}, values[pos:]...)...)
+	case p.accept("RENAME", "VALUE"):
+		from := p.next().text
+		if p.accept("TO") {
+			to := p.next().text
+			for i, v := range values {
+				if v == from {
+					values[i] = to
+				}
+			}
+		}
+	case p.accept("RENAME", "TO"):
+		newName, err := p.name()
+		if err != nil {
+			return err
+		}
+		delete(p.enums, name)
+		name = newName
+	}
+	p.enums[name] = values
+	return nil
+}
+
+// stringLiterals collects the string literals from the tokens, e.g. the values of ENUM('a', 'b')
+func stringLiterals(tokens []ddlToken) []string {
+	values := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if t.kind == tkString {
+			values = append(values, t.text)
+		}
+	}
+	return values
+}
+
+func (p *ddlParser) parseCreateTable() error {
+	p.accept("IF", "NOT", "EXISTS")
+	schema, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	if p.accept("AS") || p.accept("LIKE") {
+		return fmt.Errorf("[%s] CREATE TABLE ... AS/LIKE is not supported", name)
+	}
+	group, err := p.group()
+	if err != nil {
+		return fmt.Errorf("[%s] %s", name, err)
+	}
+
+	table := &ddlTable{schema: schema, name: name}
+	for _, item := range splitItems(group) {
+		if len(item) == 0 {
+			continue
+		}
+		ip := &ddlParser{tokens: item, dialect: p.dialect, tables: p.tables, enums: p.enums}
+		if ip.isTableConstraint() {
+			err = ip.parseTableConstraint(table)
+		} else {
+			err = ip.parseColumn(table)
+		}
+		if err != nil {
+			return fmt.Errorf("[%s] %s", name, err)
+		}
+	}
+	p.tables[name] = table
+	p.created = append(p.created, name)
+	return nil
+}
+
+func (p *ddlParser) isTableConstraint() bool {
+	for _, w := range []string{"CONSTRAINT", "PRIMARY", "UNIQUE", "KEY", "INDEX", "FULLTEXT", "SPATIAL", "FOREIGN", "CHECK", "EXCLUDE"} {
+		if p.peek().is(w) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ddlParser) indexName(table *ddlTable, columns []string, suffix string) string {
+	if p.dialect == "mysql" {
+		switch suffix {
+		case "pkey":
+			return "PRIMARY"
+		case "fkey":
+			return fmt.Sprintf("%s_ibfk_%s", table.name, strings.Join(columns, "_"))
+		}
+		if len(columns) > 0 {
+			return columns[0]
+		}
+	}
+	return fmt.Sprintf("%s_%s_%s", table.name, strings.Join(columns, "_"), suffix)
+}
+
+func (p *ddlParser) parseTableConstraint(table *ddlTable) error {
+	constraintName := ""
+	if p.accept("CONSTRAINT") {
+		if !p.peek().is("PRIMARY") && !p.peek().is("UNIQUE") && !p.peek().is("FOREIGN") && !p.peek().is("CHECK") {
+			constraintName, _ = p.name()
+		}
+	}
+
+	switch {
+	case p.accept("PRIMARY", "KEY"):
+		p.skipIndexOptions()
+		columns, err := p.nameList()
+		if err != nil {
+			return err
+		}
+		if constraintName == "" {
+			constraintName = p.indexName(table, columns, "pkey")
+		}
+		table.indexes = append(table.indexes, ddlIndex{constraintName, columns, true, true})
+	case p.accept("UNIQUE"), p.accept("KEY"), p.accept("INDEX"), p.accept("FULLTEXT"), p.accept("SPATIAL"):
+		isUnique := p.tokens[p.pos-1].is("UNIQUE")
+		if !p.accept("KEY") {
+			p.accept("INDEX")
+		}
+		if !p.peek().isPunct("(") && !p.peek().is("USING") {
+			name, _ := p.name()
+			if constraintName == "" {
+				constraintName = name
+			}
+		}
+		p.skipIndexOptions()
+		columns, err := p.nameList()
+		if err != nil {
+			return err
+		}
+		if constraintName == "" {
+			suffix := "idx"
+			if isUnique {
+				suffix = "key"
+			}
+			constraintName = p.indexName(table, columns, suffix)
+		}
+		table.indexes = append(table.indexes, ddlIndex{constraintName, columns, isUnique, false})
+	case p.accept("FOREIGN", "KEY"):
+		if !p.peek().isPunct("(") {
+			name, _ := p.name()
+			if constraintName == "" {
+				constraintName = name
+			}
+		}
+		columns, err := p.nameList()
+		if err != nil {
+			return err
+		}
+		if constraintName == "" {
+			constraintName = p.indexName(table, columns, "fkey")
+		}
+		if !p.accept("REFERENCES") {
+			return fmt.Errorf("Expect REFERENCES for the foreign key %s", constraintName)
+		}
+		fKeys, err := p.references(table, constraintName, len(columns))
+		if err != nil {
+			return err
+		}
+		for i, name := range columns {
+			_, col := table.column(name)
+			if col == nil {
+				return fmt.Errorf("Column %s of the foreign key %s is not defined", name, constraintName)
+			}
+			col.foreignKey = fKeys[i]
+		}
+		// InnoDB creates an index for the foreign key automatically if there is no one could be used
+		if p.dialect == "mysql" && !table.hasIndexPrefix(columns) {
+			table.indexes = append(table.indexes, ddlIndex{constraintName, columns, false, false})
+		}
+	}
+	// CHECK and EXCLUDE would be ignored
+	return nil
+}
+
+func (p *ddlParser) skipIndexOptions() {
+	if p.accept("USING") {
+		p.next()
+	}
+}
+
+var ddlColumnStopWords = []string{
+	"NOT", "NULL", "DEFAULT", "PRIMARY", "UNIQUE", "KEY", "REFERENCES", "CHECK", "CONSTRAINT", "COMMENT",
+	"AUTO_INCREMENT", "AUTOINCREMENT", "COLLATE", "CHARSET", "ON", "GENERATED", "AS", "STORED", "VIRTUAL",
+	"INVISIBLE", "VISIBLE", "COLUMN_FORMAT", "STORAGE", "IDENTITY", "SRID",
+}
+
+func (p *ddlParser) isColumnStopWord(t ddlToken) bool {
+	if t.kind != tkIdent {
+		return true
+	}
+	for _, w := range ddlColumnStopWords {
+		if t.is(w) {
+			return true
+		}
+	}
+	// "CHARACTER SET utf8" is not the type of character
+	return t.is("CHARACTER") && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].is("SET")
+}
+
+func (p *ddlParser) parseColumn(table *ddlTable) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	col := &ddlColumn{name: name, isNullable: true}
+	constraintName := ""
+
+	// Type names and the arguments, e.g. "double precision", "decimal(12, 2) unsigned", "integer[]"
+	words := make([]string, 0, 2)
+	columnType := ""
+	for !p.eof() && !p.isColumnStopWord(p.peek()) {
+		word := strings.ToLower(p.next().text)
+		words = append(words, word)
+		if columnType != "" {
+			columnType += " "
+		}
+		columnType += word
+		if p.peek().isPunct("(") {
+			args, _ := p.group()
+			if word == "enum" && p.dialect == "mysql" {
+				col.enumValues = stringLiterals(args)
+			}
+			argv := make([]string, len(args))
+			for i, arg := range args {
+				argv[i] = arg.text
+				if arg.kind == tkString {
+					argv[i] = "'" + arg.text + "'"
+				}
+			}
+			columnType += "(" + strings.Join(argv, "") + ")"
+		}
+		for p.peek().isPunct("[") {
+			p.next()
+			p.acceptPunct("]")
+			columnType += "[]"
+		}
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("Column %s has no type", name)
+	}
+	col.columnType = columnType
+	typeName := strings.Join(words, " ")
+	if p.dialect == "mysql" {
+		typeName = words[0]
+	} else if p.dialect == "postgres" && strings.HasSuffix(columnType, "[]") {
+		typeName += "[]"
+	}
+	switch typeName {
+	case "serial", "bigserial", "smallserial", "serial2", "serial4", "serial8":
+		if p.dialect == "postgres" {
+			col.isAutoIncrement = true
+			col.isNullable = false
+			col.defaultValue = fmt.Sprintf("nextval('%s_%s_seq'::regclass)", table.name, name)
+		} else if p.dialect == "mysql" {
+			// SERIAL is an alias for BIGINT UNSIGNED NOT NULL AUTO_INCREMENT UNIQUE
+			col.isAutoIncrement = true
+			col.isNullable = false
+			table.indexes = append(table.indexes, ddlIndex{name, []string{name}, true, false})
+		}
+	}
+	col.dataType = DdlDriver{p.dialect}.dataType(typeName, columnType)
+	if _, ok := p.enums[typeName]; ok && p.dialect == "postgres" {
+		col.enumType = typeName
+	}
+
+	for !p.eof() {
+		switch {
+		case p.accept("NOT", "NULL"):
+			col.isNullable = false
+		case p.accept("NULL"):
+			col.isNullable = true
+		case p.accept("DEFAULT"):
+			col.defaultValue = p.expression()
+		case p.accept("AUTO_INCREMENT"), p.accept("AUTOINCREMENT"):
+			col.isAutoIncrement = true
+		case p.accept("PRIMARY", "KEY"), p.accept("KEY"):
+			table.indexes = append(table.indexes, ddlIndex{p.indexName(table, []string{name}, "pkey"), []string{name}, true, true})
+			if !p.accept("ASC") {
+				p.accept("DESC")
+			}
+			if p.accept("AUTOINCREMENT") {
+				col.isAutoIncrement = true
+			}
+		case p.accept("UNIQUE"):
+			p.accept("KEY")
+			table.indexes = append(table.indexes, ddlIndex{p.indexName(table, []string{name}, "key"), []string{name}, true, false})
+		case p.accept("COMMENT"):
+			col.comment = p.next().text
+		case p.accept("ON", "UPDATE"):
+			col.onUpdate = strings.ToUpper(p.expression())
+		case p.accept("REFERENCES"):
+			if constraintName == "" {
+				constraintName = p.indexName(table, []string{name}, "fkey")
+			}
+			fKeys, err := p.references(table, constraintName, 1)
+			if err != nil {
+				return err
+			}
+			// MySQL parses but ignores the inline REFERENCES of the column definition
+			if p.dialect != "mysql" {
+				col.foreignKey = fKeys[0]
+			}
+		case p.accept("CONSTRAINT"):
+			constraintName, _ = p.name()
+		case p.accept("CHECK"):
+			p.group()
+		case p.accept("GENERATED"):
+			if p.accept("ALWAYS") || p.accept("BY", "DEFAULT") {
+				if p.accept("AS", "IDENTITY") {
+					col.isAutoIncrement = true
+					col.isNullable = false
+					if p.peek().isPunct("(") {
+						p.group()
+					}
+				} else if p.accept("AS") {
+					p.group()
+				}
+			}
+		case p.accept("AS"):
+			p.group()
+		case p.accept("COLLATE"), p.accept("CHARSET"), p.accept("CHARACTER", "SET"),
+			p.accept("COLUMN_FORMAT"), p.accept("STORAGE"), p.accept("SRID"):
+			p.next()
+		default:
+			p.next()
+		}
+	}
+
+	table.columns = append(table.columns, col)
+	return nil
+}
+
+// references parses "REFERENCES t (a, b) ON DELETE CASCADE" for the count of the columns, the referenced
+// columns would be the primary keys of the table if they are omitted.
+func (p *ddlParser) references(table *ddlTable, constraintName string, count int) ([]*ForeignKey, error) {
+	refSchema, refTable, err := p.qualifiedName()
+	if err != nil {
+		return nil, err
+	}
+	var refColumns []string
+	if p.peek().isPunct("(") {
+		if refColumns, err = p.nameList(); err != nil {
+			return nil, err
+		}
+	} else if refTable == table.name {
+		refColumns = table.primaryKeys()
+	} else if ref, ok := p.tables[refTable]; ok {
+		refColumns = ref.primaryKeys()
+	}
+	if len(refColumns) != count {
+		return nil, fmt.Errorf("The foreign key %s doesn't match the referenced columns of %s", constraintName, refTable)
+	}
+
+	onDelete, onUpdate := "NO ACTION", "NO ACTION"
+	for parsing := true; parsing && !p.eof(); {
+		switch {
+		case p.accept("ON", "DELETE"):
+			onDelete = p.referenceAction()
+		case p.accept("ON", "UPDATE"):
+			onUpdate = p.referenceAction()
+		case p.accept("MATCH"):
+			p.next()
+		case p.accept("NOT", "DEFERRABLE"), p.accept("DEFERRABLE"), p.accept("INITIALLY", "DEFERRED"), p.accept("INITIALLY", "IMMEDIATE"):
+		default:
+			parsing = false
+		}
+	}
+
+	fKeys := make([]*ForeignKey, count)
+	for i := range fKeys {
+		fKeys[i] = &ForeignKey{
+			ConstraintName: constraintName,
+			RefSchema:      refSchema,
+			RefTable:       refTable,
+			RefColumn:      refColumns[i],
+			OnDelete:       onDelete,
+			OnUpdate:       onUpdate,
+		}
+	}
+	return fKeys, nil
+}
+
+func (p *ddlParser) referenceAction() string {
+	switch {
+	case p.accept("SET", "NULL"):
+		return "SET NULL"
+	case p.accept("SET", "DEFAULT"):
+		return "SET DEFAULT"
+	case p.accept("NO", "ACTION"):
+		return "NO ACTION"
+	}
+	return strings.ToUpper(p.next().text)
+}
+
+// expression reads the default value, a string literal would be unquoted and the type casts of
+// postgres would be dropped, e.g. 'hello'::character varying => hello, NULL => "".
+func (p *ddlParser) expression() string {
+	parts := make([]string, 0, 2)
+	depth := 0
+	for !p.eof() {
+		t := p.peek()
+		if depth == 0 && len(parts) > 0 && t.kind == tkIdent && p.isColumnStopWord(t) {
+			break
+		}
+		if depth == 0 && t.isPunct("::") {
+			// skip the type cast
+			p.next()
+			for !p.eof() && p.peek().kind == tkIdent && !p.isColumnStopWord(p.peek()) {
+				p.next()
+			}
+			continue
+		}
+		if t.isPunct("(") {
+			depth++
+		} else if t.isPunct(")") {
+			depth--
+		}
+		p.next()
+		if t.kind == tkString && depth == 0 && len(parts) == 0 {
+			parts = append(parts, t.text)
+			break
+		}
+		parts = append(parts, t.text)
+	}
+	// the skipped type cast after the string literal
+	for p.peek().isPunct("::") {
+		p.next()
+		for !p.eof() && p.peek().kind == tkIdent && !p.isColumnStopWord(p.peek()) {
+			p.next()
+		}
+	}
+	value := strings.Join(parts, "")
+	if strings.EqualFold(value, "NULL") {
+		return ""
+	}
+	return value
+}
+
+func (p *ddlParser) parseCreateIndex(isUnique bool) error {
+	p.accept("CONCURRENTLY")
+	p.accept("IF", "NOT", "EXISTS")
+	name := ""
+	if !p.peek().is("ON") {
+		var err error
+		if _, name, err = p.qualifiedName(); err != nil {
+			return err
+		}
+	}
+	p.skipIndexOptions()
+	if !p.accept("ON") {
+		return fmt.Errorf("Expect ON for the index %s", name)
+	}
+	p.accept("ONLY")
+	_, tName, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	table, err := p.table(tName)
+	if err != nil {
+		return err
+	}
+	p.skipIndexOptions()
+	columns, err := p.nameList()
+	if err != nil {
+		return fmt.Errorf("[%s] %s", tName, err)
+	}
+	if name == "" {
+		suffix := "idx"
+		if isUnique {
+			suffix = "key"
+		}
+		name = p.indexName(table, columns, suffix)
+	}
+	// the partial unique index, e.g. WHERE deleted_at IS NULL, is not unique for all the rows
+	for !p.eof() {
+		if p.next().is("WHERE") {
+			isUnique = false
+		}
+	}
+	table.indexes = append(table.indexes, ddlIndex{name, columns, isUnique, false})
+	return nil
+}
+
+func (p *ddlParser) parseAlterTable() error {
+	p.accept("IF", "EXISTS")
+	p.accept("ONLY")
+	_, tName, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	table, ok := p.tables[tName]
+	if !ok {
+		// the table may be excluded or created outside of the ddl files
+		return nil
+	}
+
+	rest := p.tokens[p.pos:]
+	for _, action := range splitItems(rest) {
+		ap := &ddlParser{tokens: action, dialect: p.dialect, tables: p.tables, enums: p.enums}
+		if err := ap.parseAlterAction(table); err != nil {
+			return fmt.Errorf("[%s] %s", tName, err)
+		}
+		p.created = append(p.created, ap.created...)
+	}
+	return nil
+}
+
+func (p *ddlParser) parseAlterAction(table *ddlTable) error {
+	switch {
+	case p.accept("ADD"):
+		if p.isTableConstraint() {
+			return p.parseTableConstraint(table)
+		}
+		p.accept("COLUMN")
+		p.accept("IF", "NOT", "EXISTS")
+		return p.parseColumn(table)
+	case p.accept("DROP", "PRIMARY", "KEY"):
+		for i, index := range table.indexes {
+			if index.isPrimary {
+				table.indexes = append(table.indexes[:i], table.indexes[i+1:]...)
+				break
+			}
+		}
+	case p.accept("DROP", "INDEX"), p.accept("DROP", "KEY"), p.accept("DROP", "CONSTRAINT"), p.accept("DROP", "FOREIGN", "KEY"):
+		p.accept("IF", "EXISTS")
+		if name, err := p.name(); err == nil {
+			table.dropIndex(name)
+			for _, col := range table.columns {
+				if col.foreignKey != nil && strings.EqualFold(col.foreignKey.ConstraintName, name) {
+					col.foreignKey = nil
+				}
+			}
+		}
+	case p.accept("DROP"):
+		p.accept("COLUMN")
+		p.accept("IF", "EXISTS")
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		if i, _ := table.column(name); i >= 0 {
+			table.columns = append(table.columns[:i], table.columns[i+1:]...)
+		}
+	case p.accept("MODIFY"), p.accept("CHANGE"):
+		isChange := p.tokens[p.pos-1].is("CHANGE")
+		p.accept("COLUMN")
+		oldName := ""
+		if isChange {
+			oldName, _ = p.name()
+		} else {
+			oldName = p.peek().text
+		}
+		i, _ := table.column(oldName)
+		if i < 0 {
+			return fmt.Errorf("Column %s is not defined", oldName)
+		}
+		columns := table.columns
+		table.columns = nil
+		if err := p.parseColumn(table); err != nil {
+			table.columns = columns
+			return err
+		}
+		col := table.columns[0]
+		// the foreign keys of MySQL are the table constraints, which are kept by MODIFY and CHANGE
+		if col.foreignKey == nil {
+			col.foreignKey = columns[i].foreignKey
+		}
+		columns[i] = col
+		table.columns = columns
+		if !strings.EqualFold(oldName, col.name) {
+			p.renameColumn(table, oldName, col.name)
+		}
+	case p.accept("RENAME", "COLUMN"), p.accept("RENAME"):
+		if p.accept("TO") {
+			// rename the table
+			_, name, err := p.qualifiedName()
+			if err != nil {
+				return err
+			}
+			p.renameTable(table, name)
+			return nil
+		}
+		oldName, err := p.name()
+		if err != nil {
+			return err
+		}
+		p.accept("TO")
+		newName, err := p.name()
+		if err != nil {
+			return err
+		}
+		if _, col := table.column(oldName); col != nil {
+			col.name = newName
+			p.renameColumn(table, oldName, newName)
+		}
+	case p.accept("ALTER"):
+		p.accept("COLUMN")
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		_, col := table.column(name)
+		if col == nil {
+			return fmt.Errorf("Column %s is not defined", name)
+		}
+		switch {
+		case p.accept("TYPE"), p.accept("SET", "DATA", "TYPE"):
+			return p.retypeColumn(table, col)
+		case p.accept("SET", "DEFAULT"):
+			col.defaultValue = p.expression()
+		case p.accept("DROP", "DEFAULT"):
+			col.defaultValue = ""
+		case p.accept("SET", "NOT", "NULL"):
+			col.isNullable = false
+		case p.accept("DROP", "NOT", "NULL"):
+			col.isNullable = true
+		}
+	}
+	return nil
+}
+
+// retypeColumn changes the type of the column by the type definition of ALTER COLUMN, e.g. TYPE bigint USING age::bigint,
+// the others like the nullable and default value are not changed.
+func (p *ddlParser) retypeColumn(table *ddlTable, col *ddlColumn) error {
+	tokens := []ddlToken{{kind: tkQuoted, text: col.name}}
+	for !p.eof() && !p.peek().is("USING") && !p.peek().is("COLLATE") {
+		tokens = append(tokens, p.next())
+	}
+	tp := &ddlParser{tokens: tokens, dialect: p.dialect, tables: p.tables, enums: p.enums}
+	retyped := &ddlTable{schema: table.schema, name: table.name}
+	if err := tp.parseColumn(retyped); err != nil {
+		return err
+	}
+	newCol := retyped.columns[0]
+	col.columnType, col.dataType = newCol.columnType, newCol.dataType
+	col.enumValues, col.enumType = newCol.enumValues, newCol.enumType
+	return nil
+}
+
+// renameTable renames the table and the foreign keys referencing it from the other tables
+func (p *ddlParser) renameTable(table *ddlTable, name string) {
+	for _, t := range p.tables {
+		for _, col := range t.columns {
+			if col.foreignKey != nil && strings.EqualFold(col.foreignKey.RefTable, table.name) {
+				col.foreignKey.RefTable = name
+			}
+		}
+	}
+	delete(p.tables, table.name)
+	table.name = name
+	p.tables[name] = table
+	p.created = append(p.created, name)
+}
+
+// renameColumn renames the column in the indexes of the table and the foreign keys referencing it
+func (p *ddlParser) renameColumn(table *ddlTable, oldName, newName string) {
+	for _, index := range table.indexes {
+		for j := range index.columns {
+			if strings.EqualFold(index.columns[j], oldName) {
+				index.columns[j] = newName
+			}
+		}
+	}
+	for _, t := range p.tables {
+		for _, col := range t.columns {
+			fKey := col.foreignKey
+			if fKey != nil && strings.EqualFold(fKey.RefTable, table.name) && strings.EqualFold(fKey.RefColumn, oldName) {
+				fKey.RefColumn = newName
+			}
+		}
+	}
+}
+
+func (p *ddlParser) parseCommentOnColumn() error {
+	names := make([]string, 0, 3)
+	for {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+		if !p.acceptPunct(".") {
+			break
+		}
+	}
+	if len(names) < 2 || !p.accept("IS") {
+		return fmt.Errorf("Invalid COMMENT ON COLUMN statement")
+	}
+	comment := p.next()
+	table, ok := p.tables[names[len(names)-2]]
+	if !ok {
+		return nil
+	}
+	if _, col := table.column(names[len(names)-1]); col != nil && comment.kind == tkString {
+		col.comment = comment.text
+	}
+	return nil
+}
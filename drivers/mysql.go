@@ -1,6 +1,7 @@
 package drivers
 
 import (
+	"fmt"
 	"log"
 	"strings"
 
@@ -30,34 +31,144 @@ func (m MysqlDriver) LoadDatabaseSchema(dsnString, schema, tableNames string) (D
 	return dbSchema, nil
 }
 
-func (m MysqlDriver) dataType(colDataType string) string {
+// dataType maps the DATA_TYPE into the go type, and the COLUMN_TYPE tells if the integer is unsigned,
+// e.g. "int(10) unsigned" would be uint32 and "bigint(20) unsigned" would be uint64.
+func (m MysqlDriver) dataType(colDataType string, colType string) string {
 	kFieldTypes := map[string]string{
 		"bigint":     "int64",
 		"int":        "int",
+		"mediumint":  "int",
 		"tinyint":    "int",
 		"smallint":   "int",
+		"year":       "int",
 		"char":       "string",
 		"varchar":    "string",
+		"tinytext":   "string",
+		"text":       "string",
+		"mediumtext": "string",
+		"longtext":   "string",
+		"enum":       "string",
+		"set":        "[]string",
+		"binary":     "[]byte",
+		"varbinary":  "[]byte",
 		"tinyblob":   "[]byte",
 		"blob":       "[]byte",
 		"mediumblob": "[]byte",
-		"largeblob":  "[]byte",
+		"longblob":   "[]byte",
+		"json":       "json.RawMessage",
 		"date":       "time.Time",
 		"datetime":   "time.Time",
 		"timestamp":  "time.Time",
+		"time":       "time.Duration",
 		"float":      "float64",
 		"decimal":    "float64",
 		"double":     "float64",
 		"bit":        "uint64",
 	}
-	if fieldType, ok := kFieldTypes[strings.ToLower(colDataType)]; !ok {
+	kUnsignedTypes := map[string]string{
+		"bigint": "uint64",
+		"int":    "uint32",
+	}
+	colDataType = strings.ToLower(colDataType)
+	if strings.Contains(strings.ToLower(colType), "unsigned") {
+		if fieldType, ok := kUnsignedTypes[colDataType]; ok {
+			return fieldType
+		}
+	}
+	if fieldType, ok := kFieldTypes[colDataType]; !ok {
 		return "string"
 	} else {
 		return fieldType
 	}
 }
 
+func (m MysqlDriver) queryForeignKeys(db *gmq.Db, dbName string, tables string) (map[string]*ForeignKey, error) {
+	// FIXME: if we have implemented the JOIN
+	fKeys := make(map[string]*ForeignKey)
+
+	rcObjs := mysql.ReferentialConstraintsObjs
+	kcuObjs := mysql.KeyColumnUsageObjs
+	rcFilter := rcObjs.FilterConstraintSchema("=", dbName)
+	kcuFilter := kcuObjs.FilterTableSchema("=", dbName).And(kcuObjs.FilterReferencedTableName("<>", ""))
+	if len(tables) > 0 {
+		tableVs := strings.Split(tables, ",")
+		rcFilter = rcFilter.And(rcObjs.FilterTableName("IN", tableVs[0], tableVs[1:]...))
+		kcuFilter = kcuFilter.And(kcuObjs.FilterTableName("IN", tableVs[0], tableVs[1:]...))
+	}
+
+	rules := make(map[string]mysql.ReferentialConstraints)
+	err := rcObjs.Select().Where(rcFilter).Iterate(db, func(rc mysql.ReferentialConstraints) bool {
+		key := fmt.Sprintf("%s.%s", rc.TableName, rc.ConstraintName)
+		rules[key] = rc
+		return true
+	})
+	if err != nil {
+		return fKeys, err
+	}
+
+	err = kcuObjs.Select().Where(kcuFilter).Iterate(db, func(kcu mysql.KeyColumnUsage) bool {
+		fKey := &ForeignKey{
+			ConstraintName: kcu.ConstraintName,
+			RefSchema:      kcu.ReferencedTableSchema,
+			RefTable:       kcu.ReferencedTableName,
+			RefColumn:      kcu.ReferencedColumnName,
+		}
+		if rc, ok := rules[fmt.Sprintf("%s.%s", kcu.TableName, kcu.ConstraintName)]; ok {
+			fKey.OnDelete = rc.DeleteRule
+			fKey.OnUpdate = rc.UpdateRule
+		}
+		fKeys[fmt.Sprintf("%s.%s", kcu.TableName, kcu.ColumnName)] = fKey
+		return true
+	})
+	return fKeys, err
+}
+
+func (m MysqlDriver) queryIndexes(db *gmq.Db, dbName string, tables string) (map[string][]*Index, error) {
+	indexes := make(map[string][]*Index)
+
+	objs := mysql.StatisticsObjs
+	filter := objs.FilterTableSchema("=", dbName)
+	if len(tables) > 0 {
+		tableVs := strings.Split(tables, ",")
+		filter = filter.And(objs.FilterTableName("IN", tableVs[0], tableVs[1:]...))
+	}
+
+	tableIndexes := make(map[string]*Index)
+	query := objs.Select().Where(filter).OrderBy("TableName", "IndexName", "SeqInIndex")
+	err := query.Iterate(db, func(stat mysql.Statistics) bool {
+		key := fmt.Sprintf("%s.%s", stat.TableName, stat.IndexName)
+		index, ok := tableIndexes[key]
+		if !ok {
+			index = &Index{
+				Name:      stat.IndexName,
+				Columns:   make([]string, 0, 2),
+				IsUnique:  stat.NonUnique == 0,
+				IsPrimary: stat.IndexName == "PRIMARY",
+			}
+			tableIndexes[key] = index
+			indexes[stat.TableName] = append(indexes[stat.TableName], index)
+		}
+		index.Columns = append(index.Columns, stat.ColumnName)
+		return true
+	})
+	return indexes, err
+}
+
 func (m MysqlDriver) queryColumns(db *gmq.Db, dbName string, tables string, dbSchema DbSchema) error {
+	tableFilter, err := NewTableFilter(tables)
+	if err != nil {
+		return err
+	}
+	tables = tableFilter.Names()
+	fKeys, err := m.queryForeignKeys(db, dbName, tables)
+	if err != nil {
+		return err
+	}
+	indexes, err := m.queryIndexes(db, dbName, tables)
+	if err != nil {
+		return err
+	}
+
 	objs := mysql.ColumnsObjs
 	filter := objs.FilterTableSchema("=", dbName)
 	if len(tables) > 0 {
@@ -66,7 +177,10 @@ func (m MysqlDriver) queryColumns(db *gmq.Db, dbName string, tables string, dbSc
 	}
 
 	query := objs.Select().Where(filter).OrderBy("TableName", "OrdinalPosition")
-	return query.Iterate(db, func(col mysql.Columns) bool {
+	err = query.Iterate(db, func(col mysql.Columns) bool {
+		if !tableFilter.Match(col.TableName) {
+			return true
+		}
 		if _, ok := dbSchema[col.TableName]; !ok {
 			dbSchema[col.TableName] = make(TableSchema, 0, 5)
 		}
@@ -75,16 +189,32 @@ func (m MysqlDriver) queryColumns(db *gmq.Db, dbName string, tables string, dbSc
 			TableName:    col.TableName,
 			ColumnName:   col.ColumnName,
 			DefaultValue: col.ColumnDefault,
-			DataType:     m.dataType(col.DataType),
+			DataType:     m.dataType(col.DataType, col.ColumnType),
 			ColumnType:   col.ColumnType,
 			ColumnKey:    col.ColumnKey,
 			Extra:        col.Extra,
 			Comment:      col.ColumnComment,
 			IsNullable:   col.IsNullable,
+			ForeignKey:   fKeys[fmt.Sprintf("%s.%s", col.TableName, col.ColumnName)],
+
+			NumericPrecision: int(col.NumericPrecision),
+			NumericScale:     int(col.NumericScale),
+			CharMaxLength:    int(col.CharacterMaximumLength),
+		}
+		if strings.ToLower(col.DataType) == "enum" {
+			sCol.EnumValues = parseEnumValues(col.ColumnType)
 		}
 		dbSchema[col.TableName] = append(dbSchema[col.TableName], sCol)
 		return true
 	})
+	if err != nil {
+		return err
+	}
+
+	for tableName, tableSchema := range dbSchema {
+		tableSchema.setIndexes(indexes[tableName])
+	}
+	return nil
 }
 
 func (m MysqlDriver) useInformationSchema(dsn string, schema string) string {
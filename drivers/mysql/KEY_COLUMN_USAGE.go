@@ -0,0 +1,530 @@
+// Code generated by ModelQ
+// KEY_COLUMN_USAGE.go contains model for the database table [information_schema.KEY_COLUMN_USAGE]
+
+package mysql
+
+import (
+	"encoding/gob"
+	"encoding/json"
+
+	"database/sql"
+	"github.com/mijia/modelq/gmq"
+	"strings"
+)
+
+type KeyColumnUsage struct {
+	ConstraintCatalog          string `json:"CONSTRAINT_CATALOG"`
+	ConstraintSchema           string `json:"CONSTRAINT_SCHEMA"`
+	ConstraintName             string `json:"CONSTRAINT_NAME"`
+	TableCatalog               string `json:"TABLE_CATALOG"`
+	TableSchema                string `json:"TABLE_SCHEMA"`
+	TableName                  string `json:"TABLE_NAME"`
+	ColumnName                 string `json:"COLUMN_NAME"`
+	OrdinalPosition            int64  `json:"ORDINAL_POSITION"`
+	PositionInUniqueConstraint int64  `json:"POSITION_IN_UNIQUE_CONSTRAINT"`
+	ReferencedTableSchema      string `json:"REFERENCED_TABLE_SCHEMA"`
+	ReferencedTableName        string `json:"REFERENCED_TABLE_NAME"`
+	ReferencedColumnName       string `json:"REFERENCED_COLUMN_NAME"`
+}
+
+// Start of the KeyColumnUsage APIs.
+
+func (obj KeyColumnUsage) String() string {
+	if data, err := json.Marshal(obj); err != nil {
+		return "<KeyColumnUsage>"
+	} else {
+		return string(data)
+	}
+}
+
+func (obj KeyColumnUsage) Get(dbtx gmq.DbTx) (KeyColumnUsage, error) {
+	return obj, gmq.ErrNoPrimaryKeyDefined
+}
+
+func (obj KeyColumnUsage) Insert(dbtx gmq.DbTx) (KeyColumnUsage, error) {
+	_, err := KeyColumnUsageObjs.Insert(obj).Run(dbtx)
+	return obj, err
+}
+
+func (obj KeyColumnUsage) Update(dbtx gmq.DbTx) (int64, error) {
+	return 0, gmq.ErrNoPrimaryKeyDefined
+}
+
+func (obj KeyColumnUsage) Delete(dbtx gmq.DbTx) (int64, error) {
+	return 0, gmq.ErrNoPrimaryKeyDefined
+}
+
+// Start of the inner Query Api
+
+type _KeyColumnUsageQuery struct {
+	gmq.Query
+}
+
+func (q _KeyColumnUsageQuery) Where(f gmq.Filter) _KeyColumnUsageQuery {
+	q.Query = q.Query.Where(f)
+	return q
+}
+
+func (q _KeyColumnUsageQuery) OrderBy(by ...string) _KeyColumnUsageQuery {
+	tBy := make([]string, 0, len(by))
+	for _, b := range by {
+		sortDir := ""
+		if b[0] == '-' || b[0] == '+' {
+			sortDir = string(b[0])
+			b = b[1:]
+		}
+		if col, ok := KeyColumnUsageObjs.fcMap[b]; ok {
+			tBy = append(tBy, sortDir+col)
+		}
+	}
+	q.Query = q.Query.OrderBy(tBy...)
+	return q
+}
+
+func (q _KeyColumnUsageQuery) GroupBy(by ...string) _KeyColumnUsageQuery {
+	tBy := make([]string, 0, len(by))
+	for _, b := range by {
+		if col, ok := KeyColumnUsageObjs.fcMap[b]; ok {
+			tBy = append(tBy, col)
+		}
+	}
+	q.Query = q.Query.GroupBy(tBy...)
+	return q
+}
+
+func (q _KeyColumnUsageQuery) Limit(offsets ...int64) _KeyColumnUsageQuery {
+	q.Query = q.Query.Limit(offsets...)
+	return q
+}
+
+func (q _KeyColumnUsageQuery) Page(number, size int) _KeyColumnUsageQuery {
+	q.Query = q.Query.Page(number, size)
+	return q
+}
+
+func (q _KeyColumnUsageQuery) Run(dbtx gmq.DbTx) (sql.Result, error) {
+	return q.Query.Exec(dbtx)
+}
+
+type KeyColumnUsageRowVisitor func(obj KeyColumnUsage) bool
+
+func (q _KeyColumnUsageQuery) Iterate(dbtx gmq.DbTx, functor KeyColumnUsageRowVisitor) error {
+	return q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj := KeyColumnUsageObjs.toKeyColumnUsage(columns, rb)
+		return functor(obj)
+	})
+}
+
+func (q _KeyColumnUsageQuery) One(dbtx gmq.DbTx) (KeyColumnUsage, error) {
+	var obj KeyColumnUsage
+	err := q.Query.SelectOne(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj = KeyColumnUsageObjs.toKeyColumnUsage(columns, rb)
+		return true
+	})
+	return obj, err
+}
+
+func (q _KeyColumnUsageQuery) List(dbtx gmq.DbTx) ([]KeyColumnUsage, error) {
+	result := make([]KeyColumnUsage, 0, 10)
+	err := q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj := KeyColumnUsageObjs.toKeyColumnUsage(columns, rb)
+		result = append(result, obj)
+		return true
+	})
+	return result, err
+}
+
+func (q _KeyColumnUsageQuery) Count(dbtx gmq.DbTx) (int, error) {
+	result := 0
+
+	err := q.Query.SelectCount(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		if len(columns) == len(rb) {
+			for i := range columns {
+				if "_count" == columns[i].Name {
+					result = gmq.AsInt(rb[i])
+
+					return true
+				}
+			}
+		}
+
+		return true
+	})
+
+	return result, err
+}
+
+// Start of the model facade Apis.
+
+type _KeyColumnUsageObjs struct {
+	fcMap map[string]string
+}
+
+func (o _KeyColumnUsageObjs) Names() (schema, tbl, alias string) {
+	return "information_schema", "KEY_COLUMN_USAGE", "KeyColumnUsage"
+}
+
+func (o _KeyColumnUsageObjs) Select(fields ...string) _KeyColumnUsageQuery {
+	q := _KeyColumnUsageQuery{}
+	if len(fields) == 0 {
+		fields = []string{"ConstraintCatalog", "ConstraintSchema", "ConstraintName", "TableCatalog", "TableSchema", "TableName", "ColumnName", "OrdinalPosition", "PositionInUniqueConstraint", "ReferencedTableSchema", "ReferencedTableName", "ReferencedColumnName"}
+	}
+	q.Query = gmq.Select(o, o.columns(fields...))
+	return q
+}
+
+func (o _KeyColumnUsageObjs) Insert(obj KeyColumnUsage) _KeyColumnUsageQuery {
+	q := _KeyColumnUsageQuery{}
+	q.Query = gmq.Insert(o, o.columnsWithData(obj, "ConstraintCatalog", "ConstraintSchema", "ConstraintName", "TableCatalog", "TableSchema", "TableName", "ColumnName", "OrdinalPosition", "PositionInUniqueConstraint", "ReferencedTableSchema", "ReferencedTableName", "ReferencedColumnName"))
+	return q
+}
+
+func (o _KeyColumnUsageObjs) Update(obj KeyColumnUsage, fields ...string) _KeyColumnUsageQuery {
+	q := _KeyColumnUsageQuery{}
+	q.Query = gmq.Update(o, o.columnsWithData(obj, fields...))
+	return q
+}
+
+func (o _KeyColumnUsageObjs) Delete() _KeyColumnUsageQuery {
+	q := _KeyColumnUsageQuery{}
+	q.Query = gmq.Delete(o)
+	return q
+}
+
+///// Managed Objects Filters definition
+
+func (o _KeyColumnUsageObjs) FilterConstraintCatalog(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("CONSTRAINT_CATALOG", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterConstraintSchema(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("CONSTRAINT_SCHEMA", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterConstraintName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("CONSTRAINT_NAME", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterTableCatalog(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("TABLE_CATALOG", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterTableSchema(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("TABLE_SCHEMA", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterTableName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("TABLE_NAME", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterColumnName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("COLUMN_NAME", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterOrdinalPosition(op string, p int64, ps ...int64) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("ORDINAL_POSITION", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterPositionInUniqueConstraint(op string, p int64, ps ...int64) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("POSITION_IN_UNIQUE_CONSTRAINT", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterReferencedTableSchema(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("REFERENCED_TABLE_SCHEMA", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterReferencedTableName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("REFERENCED_TABLE_NAME", op, params...)
+}
+
+func (o _KeyColumnUsageObjs) FilterReferencedColumnName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("REFERENCED_COLUMN_NAME", op, params...)
+}
+
+///// Managed Objects Columns definition
+
+func (o _KeyColumnUsageObjs) ColumnConstraintCatalog(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"CONSTRAINT_CATALOG", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnConstraintSchema(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"CONSTRAINT_SCHEMA", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnConstraintName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"CONSTRAINT_NAME", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnTableCatalog(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"TABLE_CATALOG", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnTableSchema(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"TABLE_SCHEMA", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnTableName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"TABLE_NAME", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnColumnName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"COLUMN_NAME", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnOrdinalPosition(p ...int64) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"ORDINAL_POSITION", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnPositionInUniqueConstraint(p ...int64) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"POSITION_IN_UNIQUE_CONSTRAINT", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnReferencedTableSchema(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"REFERENCED_TABLE_SCHEMA", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnReferencedTableName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"REFERENCED_TABLE_NAME", value}
+}
+
+func (o _KeyColumnUsageObjs) ColumnReferencedColumnName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"REFERENCED_COLUMN_NAME", value}
+}
+
+////// Internal helper funcs
+
+func (o _KeyColumnUsageObjs) newFilter(name, op string, params ...interface{}) gmq.Filter {
+	if strings.ToUpper(op) == "IN" {
+		return gmq.InFilter(name, params)
+	}
+	return gmq.UnitFilter(name, op, params[0])
+}
+
+func (o _KeyColumnUsageObjs) toKeyColumnUsage(columns []gmq.Column, rb []sql.RawBytes) KeyColumnUsage {
+	obj := KeyColumnUsage{}
+	if len(columns) == len(rb) {
+		for i := range columns {
+			switch columns[i].Name {
+			case "CONSTRAINT_CATALOG":
+				obj.ConstraintCatalog = gmq.AsString(rb[i])
+			case "CONSTRAINT_SCHEMA":
+				obj.ConstraintSchema = gmq.AsString(rb[i])
+			case "CONSTRAINT_NAME":
+				obj.ConstraintName = gmq.AsString(rb[i])
+			case "TABLE_CATALOG":
+				obj.TableCatalog = gmq.AsString(rb[i])
+			case "TABLE_SCHEMA":
+				obj.TableSchema = gmq.AsString(rb[i])
+			case "TABLE_NAME":
+				obj.TableName = gmq.AsString(rb[i])
+			case "COLUMN_NAME":
+				obj.ColumnName = gmq.AsString(rb[i])
+			case "ORDINAL_POSITION":
+				obj.OrdinalPosition = gmq.AsInt64(rb[i])
+			case "POSITION_IN_UNIQUE_CONSTRAINT":
+				obj.PositionInUniqueConstraint = gmq.AsInt64(rb[i])
+			case "REFERENCED_TABLE_SCHEMA":
+				obj.ReferencedTableSchema = gmq.AsString(rb[i])
+			case "REFERENCED_TABLE_NAME":
+				obj.ReferencedTableName = gmq.AsString(rb[i])
+			case "REFERENCED_COLUMN_NAME":
+				obj.ReferencedColumnName = gmq.AsString(rb[i])
+			}
+		}
+	}
+	return obj
+}
+
+func (o _KeyColumnUsageObjs) columns(fields ...string) []gmq.Column {
+	data := make([]gmq.Column, 0, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "ConstraintCatalog":
+			data = append(data, o.ColumnConstraintCatalog())
+		case "ConstraintSchema":
+			data = append(data, o.ColumnConstraintSchema())
+		case "ConstraintName":
+			data = append(data, o.ColumnConstraintName())
+		case "TableCatalog":
+			data = append(data, o.ColumnTableCatalog())
+		case "TableSchema":
+			data = append(data, o.ColumnTableSchema())
+		case "TableName":
+			data = append(data, o.ColumnTableName())
+		case "ColumnName":
+			data = append(data, o.ColumnColumnName())
+		case "OrdinalPosition":
+			data = append(data, o.ColumnOrdinalPosition())
+		case "PositionInUniqueConstraint":
+			data = append(data, o.ColumnPositionInUniqueConstraint())
+		case "ReferencedTableSchema":
+			data = append(data, o.ColumnReferencedTableSchema())
+		case "ReferencedTableName":
+			data = append(data, o.ColumnReferencedTableName())
+		case "ReferencedColumnName":
+			data = append(data, o.ColumnReferencedColumnName())
+		}
+	}
+	return data
+}
+
+func (o _KeyColumnUsageObjs) columnsWithData(obj KeyColumnUsage, fields ...string) []gmq.Column {
+	data := make([]gmq.Column, 0, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "ConstraintCatalog":
+			data = append(data, o.ColumnConstraintCatalog(obj.ConstraintCatalog))
+		case "ConstraintSchema":
+			data = append(data, o.ColumnConstraintSchema(obj.ConstraintSchema))
+		case "ConstraintName":
+			data = append(data, o.ColumnConstraintName(obj.ConstraintName))
+		case "TableCatalog":
+			data = append(data, o.ColumnTableCatalog(obj.TableCatalog))
+		case "TableSchema":
+			data = append(data, o.ColumnTableSchema(obj.TableSchema))
+		case "TableName":
+			data = append(data, o.ColumnTableName(obj.TableName))
+		case "ColumnName":
+			data = append(data, o.ColumnColumnName(obj.ColumnName))
+		case "OrdinalPosition":
+			data = append(data, o.ColumnOrdinalPosition(obj.OrdinalPosition))
+		case "PositionInUniqueConstraint":
+			data = append(data, o.ColumnPositionInUniqueConstraint(obj.PositionInUniqueConstraint))
+		case "ReferencedTableSchema":
+			data = append(data, o.ColumnReferencedTableSchema(obj.ReferencedTableSchema))
+		case "ReferencedTableName":
+			data = append(data, o.ColumnReferencedTableName(obj.ReferencedTableName))
+		case "ReferencedColumnName":
+			data = append(data, o.ColumnReferencedColumnName(obj.ReferencedColumnName))
+		}
+	}
+	return data
+}
+
+var KeyColumnUsageObjs _KeyColumnUsageObjs
+
+func init() {
+	KeyColumnUsageObjs.fcMap = map[string]string{
+		"ConstraintCatalog":          "CONSTRAINT_CATALOG",
+		"ConstraintSchema":           "CONSTRAINT_SCHEMA",
+		"ConstraintName":             "CONSTRAINT_NAME",
+		"TableCatalog":               "TABLE_CATALOG",
+		"TableSchema":                "TABLE_SCHEMA",
+		"TableName":                  "TABLE_NAME",
+		"ColumnName":                 "COLUMN_NAME",
+		"OrdinalPosition":            "ORDINAL_POSITION",
+		"PositionInUniqueConstraint": "POSITION_IN_UNIQUE_CONSTRAINT",
+		"ReferencedTableSchema":      "REFERENCED_TABLE_SCHEMA",
+		"ReferencedTableName":        "REFERENCED_TABLE_NAME",
+		"ReferencedColumnName":       "REFERENCED_COLUMN_NAME",
+	}
+	gob.Register(KeyColumnUsage{})
+}
@@ -0,0 +1,505 @@
+// Code generated by ModelQ
+// REFERENTIAL_CONSTRAINTS.go contains model for the database table [information_schema.REFERENTIAL_CONSTRAINTS]
+
+package mysql
+
+import (
+	"encoding/gob"
+	"encoding/json"
+
+	"database/sql"
+	"github.com/mijia/modelq/gmq"
+	"strings"
+)
+
+type ReferentialConstraints struct {
+	ConstraintCatalog       string `json:"CONSTRAINT_CATALOG"`
+	ConstraintSchema        string `json:"CONSTRAINT_SCHEMA"`
+	ConstraintName          string `json:"CONSTRAINT_NAME"`
+	UniqueConstraintCatalog string `json:"UNIQUE_CONSTRAINT_CATALOG"`
+	UniqueConstraintSchema  string `json:"UNIQUE_CONSTRAINT_SCHEMA"`
+	UniqueConstraintName    string `json:"UNIQUE_CONSTRAINT_NAME"`
+	MatchOption             string `json:"MATCH_OPTION"`
+	UpdateRule              string `json:"UPDATE_RULE"`
+	DeleteRule              string `json:"DELETE_RULE"`
+	TableName               string `json:"TABLE_NAME"`
+	ReferencedTableName     string `json:"REFERENCED_TABLE_NAME"`
+}
+
+// Start of the ReferentialConstraints APIs.
+
+func (obj ReferentialConstraints) String() string {
+	if data, err := json.Marshal(obj); err != nil {
+		return "<ReferentialConstraints>"
+	} else {
+		return string(data)
+	}
+}
+
+func (obj ReferentialConstraints) Get(dbtx gmq.DbTx) (ReferentialConstraints, error) {
+	return obj, gmq.ErrNoPrimaryKeyDefined
+}
+
+func (obj ReferentialConstraints) Insert(dbtx gmq.DbTx) (ReferentialConstraints, error) {
+	_, err := ReferentialConstraintsObjs.Insert(obj).Run(dbtx)
+	return obj, err
+}
+
+func (obj ReferentialConstraints) Update(dbtx gmq.DbTx) (int64, error) {
+	return 0, gmq.ErrNoPrimaryKeyDefined
+}
+
+func (obj ReferentialConstraints) Delete(dbtx gmq.DbTx) (int64, error) {
+	return 0, gmq.ErrNoPrimaryKeyDefined
+}
+
+// Start of the inner Query Api
+
+type _ReferentialConstraintsQuery struct {
+	gmq.Query
+}
+
+func (q _ReferentialConstraintsQuery) Where(f gmq.Filter) _ReferentialConstraintsQuery {
+	q.Query = q.Query.Where(f)
+	return q
+}
+
+func (q _ReferentialConstraintsQuery) OrderBy(by ...string) _ReferentialConstraintsQuery {
+	tBy := make([]string, 0, len(by))
+	for _, b := range by {
+		sortDir := ""
+		if b[0] == '-' || b[0] == '+' {
+			sortDir = string(b[0])
+			b = b[1:]
+		}
+		if col, ok := ReferentialConstraintsObjs.fcMap[b]; ok {
+			tBy = append(tBy, sortDir+col)
+		}
+	}
+	q.Query = q.Query.OrderBy(tBy...)
+	return q
+}
+
+func (q _ReferentialConstraintsQuery) GroupBy(by ...string) _ReferentialConstraintsQuery {
+	tBy := make([]string, 0, len(by))
+	for _, b := range by {
+		if col, ok := ReferentialConstraintsObjs.fcMap[b]; ok {
+			tBy = append(tBy, col)
+		}
+	}
+	q.Query = q.Query.GroupBy(tBy...)
+	return q
+}
+
+func (q _ReferentialConstraintsQuery) Limit(offsets ...int64) _ReferentialConstraintsQuery {
+	q.Query = q.Query.Limit(offsets...)
+	return q
+}
+
+func (q _ReferentialConstraintsQuery) Page(number, size int) _ReferentialConstraintsQuery {
+	q.Query = q.Query.Page(number, size)
+	return q
+}
+
+func (q _ReferentialConstraintsQuery) Run(dbtx gmq.DbTx) (sql.Result, error) {
+	return q.Query.Exec(dbtx)
+}
+
+type ReferentialConstraintsRowVisitor func(obj ReferentialConstraints) bool
+
+func (q _ReferentialConstraintsQuery) Iterate(dbtx gmq.DbTx, functor ReferentialConstraintsRowVisitor) error {
+	return q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj := ReferentialConstraintsObjs.toReferentialConstraints(columns, rb)
+		return functor(obj)
+	})
+}
+
+func (q _ReferentialConstraintsQuery) One(dbtx gmq.DbTx) (ReferentialConstraints, error) {
+	var obj ReferentialConstraints
+	err := q.Query.SelectOne(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj = ReferentialConstraintsObjs.toReferentialConstraints(columns, rb)
+		return true
+	})
+	return obj, err
+}
+
+func (q _ReferentialConstraintsQuery) List(dbtx gmq.DbTx) ([]ReferentialConstraints, error) {
+	result := make([]ReferentialConstraints, 0, 10)
+	err := q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj := ReferentialConstraintsObjs.toReferentialConstraints(columns, rb)
+		result = append(result, obj)
+		return true
+	})
+	return result, err
+}
+
+func (q _ReferentialConstraintsQuery) Count(dbtx gmq.DbTx) (int, error) {
+	result := 0
+
+	err := q.Query.SelectCount(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		if len(columns) == len(rb) {
+			for i := range columns {
+				if "_count" == columns[i].Name {
+					result = gmq.AsInt(rb[i])
+
+					return true
+				}
+			}
+		}
+
+		return true
+	})
+
+	return result, err
+}
+
+// Start of the model facade Apis.
+
+type _ReferentialConstraintsObjs struct {
+	fcMap map[string]string
+}
+
+func (o _ReferentialConstraintsObjs) Names() (schema, tbl, alias string) {
+	return "information_schema", "REFERENTIAL_CONSTRAINTS", "ReferentialConstraints"
+}
+
+func (o _ReferentialConstraintsObjs) Select(fields ...string) _ReferentialConstraintsQuery {
+	q := _ReferentialConstraintsQuery{}
+	if len(fields) == 0 {
+		fields = []string{"ConstraintCatalog", "ConstraintSchema", "ConstraintName", "UniqueConstraintCatalog", "UniqueConstraintSchema", "UniqueConstraintName", "MatchOption", "UpdateRule", "DeleteRule", "TableName", "ReferencedTableName"}
+	}
+	q.Query = gmq.Select(o, o.columns(fields...))
+	return q
+}
+
+func (o _ReferentialConstraintsObjs) Insert(obj ReferentialConstraints) _ReferentialConstraintsQuery {
+	q := _ReferentialConstraintsQuery{}
+	q.Query = gmq.Insert(o, o.columnsWithData(obj, "ConstraintCatalog", "ConstraintSchema", "ConstraintName", "UniqueConstraintCatalog", "UniqueConstraintSchema", "UniqueConstraintName", "MatchOption", "UpdateRule", "DeleteRule", "TableName", "ReferencedTableName"))
+	return q
+}
+
+func (o _ReferentialConstraintsObjs) Update(obj ReferentialConstraints, fields ...string) _ReferentialConstraintsQuery {
+	q := _ReferentialConstraintsQuery{}
+	q.Query = gmq.Update(o, o.columnsWithData(obj, fields...))
+	return q
+}
+
+func (o _ReferentialConstraintsObjs) Delete() _ReferentialConstraintsQuery {
+	q := _ReferentialConstraintsQuery{}
+	q.Query = gmq.Delete(o)
+	return q
+}
+
+///// Managed Objects Filters definition
+
+func (o _ReferentialConstraintsObjs) FilterConstraintCatalog(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("CONSTRAINT_CATALOG", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterConstraintSchema(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("CONSTRAINT_SCHEMA", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterConstraintName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("CONSTRAINT_NAME", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterUniqueConstraintCatalog(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("UNIQUE_CONSTRAINT_CATALOG", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterUniqueConstraintSchema(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("UNIQUE_CONSTRAINT_SCHEMA", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterUniqueConstraintName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("UNIQUE_CONSTRAINT_NAME", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterMatchOption(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("MATCH_OPTION", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterUpdateRule(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("UPDATE_RULE", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterDeleteRule(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("DELETE_RULE", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterTableName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("TABLE_NAME", op, params...)
+}
+
+func (o _ReferentialConstraintsObjs) FilterReferencedTableName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("REFERENCED_TABLE_NAME", op, params...)
+}
+
+///// Managed Objects Columns definition
+
+func (o _ReferentialConstraintsObjs) ColumnConstraintCatalog(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"CONSTRAINT_CATALOG", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnConstraintSchema(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"CONSTRAINT_SCHEMA", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnConstraintName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"CONSTRAINT_NAME", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnUniqueConstraintCatalog(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"UNIQUE_CONSTRAINT_CATALOG", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnUniqueConstraintSchema(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"UNIQUE_CONSTRAINT_SCHEMA", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnUniqueConstraintName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"UNIQUE_CONSTRAINT_NAME", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnMatchOption(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"MATCH_OPTION", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnUpdateRule(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"UPDATE_RULE", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnDeleteRule(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"DELETE_RULE", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnTableName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"TABLE_NAME", value}
+}
+
+func (o _ReferentialConstraintsObjs) ColumnReferencedTableName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"REFERENCED_TABLE_NAME", value}
+}
+
+////// Internal helper funcs
+
+func (o _ReferentialConstraintsObjs) newFilter(name, op string, params ...interface{}) gmq.Filter {
+	if strings.ToUpper(op) == "IN" {
+		return gmq.InFilter(name, params)
+	}
+	return gmq.UnitFilter(name, op, params[0])
+}
+
+func (o _ReferentialConstraintsObjs) toReferentialConstraints(columns []gmq.Column, rb []sql.RawBytes) ReferentialConstraints {
+	obj := ReferentialConstraints{}
+	if len(columns) == len(rb) {
+		for i := range columns {
+			switch columns[i].Name {
+			case "CONSTRAINT_CATALOG":
+				obj.ConstraintCatalog = gmq.AsString(rb[i])
+			case "CONSTRAINT_SCHEMA":
+				obj.ConstraintSchema = gmq.AsString(rb[i])
+			case "CONSTRAINT_NAME":
+				obj.ConstraintName = gmq.AsString(rb[i])
+			case "UNIQUE_CONSTRAINT_CATALOG":
+				obj.UniqueConstraintCatalog = gmq.AsString(rb[i])
+			case "UNIQUE_CONSTRAINT_SCHEMA":
+				obj.UniqueConstraintSchema = gmq.AsString(rb[i])
+			case "UNIQUE_CONSTRAINT_NAME":
+				obj.UniqueConstraintName = gmq.AsString(rb[i])
+			case "MATCH_OPTION":
+				obj.MatchOption = gmq.AsString(rb[i])
+			case "UPDATE_RULE":
+				obj.UpdateRule = gmq.AsString(rb[i])
+			case "DELETE_RULE":
+				obj.DeleteRule = gmq.AsString(rb[i])
+			case "TABLE_NAME":
+				obj.TableName = gmq.AsString(rb[i])
+			case "REFERENCED_TABLE_NAME":
+				obj.ReferencedTableName = gmq.AsString(rb[i])
+			}
+		}
+	}
+	return obj
+}
+
+func (o _ReferentialConstraintsObjs) columns(fields ...string) []gmq.Column {
+	data := make([]gmq.Column, 0, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "ConstraintCatalog":
+			data = append(data, o.ColumnConstraintCatalog())
+		case "ConstraintSchema":
+			data = append(data, o.ColumnConstraintSchema())
+		case "ConstraintName":
+			data = append(data, o.ColumnConstraintName())
+		case "UniqueConstraintCatalog":
+			data = append(data, o.ColumnUniqueConstraintCatalog())
+		case "UniqueConstraintSchema":
+			data = append(data, o.ColumnUniqueConstraintSchema())
+		case "UniqueConstraintName":
+			data = append(data, o.ColumnUniqueConstraintName())
+		case "MatchOption":
+			data = append(data, o.ColumnMatchOption())
+		case "UpdateRule":
+			data = append(data, o.ColumnUpdateRule())
+		case "DeleteRule":
+			data = append(data, o.ColumnDeleteRule())
+		case "TableName":
+			data = append(data, o.ColumnTableName())
+		case "ReferencedTableName":
+			data = append(data, o.ColumnReferencedTableName())
+		}
+	}
+	return data
+}
+
+func (o _ReferentialConstraintsObjs) columnsWithData(obj ReferentialConstraints, fields ...string) []gmq.Column {
+	data := make([]gmq.Column, 0, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "ConstraintCatalog":
+			data = append(data, o.ColumnConstraintCatalog(obj.ConstraintCatalog))
+		case "ConstraintSchema":
+			data = append(data, o.ColumnConstraintSchema(obj.ConstraintSchema))
+		case "ConstraintName":
+			data = append(data, o.ColumnConstraintName(obj.ConstraintName))
+		case "UniqueConstraintCatalog":
+			data = append(data, o.ColumnUniqueConstraintCatalog(obj.UniqueConstraintCatalog))
+		case "UniqueConstraintSchema":
+			data = append(data, o.ColumnUniqueConstraintSchema(obj.UniqueConstraintSchema))
+		case "UniqueConstraintName":
+			data = append(data, o.ColumnUniqueConstraintName(obj.UniqueConstraintName))
+		case "MatchOption":
+			data = append(data, o.ColumnMatchOption(obj.MatchOption))
+		case "UpdateRule":
+			data = append(data, o.ColumnUpdateRule(obj.UpdateRule))
+		case "DeleteRule":
+			data = append(data, o.ColumnDeleteRule(obj.DeleteRule))
+		case "TableName":
+			data = append(data, o.ColumnTableName(obj.TableName))
+		case "ReferencedTableName":
+			data = append(data, o.ColumnReferencedTableName(obj.ReferencedTableName))
+		}
+	}
+	return data
+}
+
+var ReferentialConstraintsObjs _ReferentialConstraintsObjs
+
+func init() {
+	ReferentialConstraintsObjs.fcMap = map[string]string{
+		"ConstraintCatalog":       "CONSTRAINT_CATALOG",
+		"ConstraintSchema":        "CONSTRAINT_SCHEMA",
+		"ConstraintName":          "CONSTRAINT_NAME",
+		"UniqueConstraintCatalog": "UNIQUE_CONSTRAINT_CATALOG",
+		"UniqueConstraintSchema":  "UNIQUE_CONSTRAINT_SCHEMA",
+		"UniqueConstraintName":    "UNIQUE_CONSTRAINT_NAME",
+		"MatchOption":             "MATCH_OPTION",
+		"UpdateRule":              "UPDATE_RULE",
+		"DeleteRule":              "DELETE_RULE",
+		"TableName":               "TABLE_NAME",
+		"ReferencedTableName":     "REFERENCED_TABLE_NAME",
+	}
+	gob.Register(ReferentialConstraints{})
+}
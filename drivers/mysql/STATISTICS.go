@@ -0,0 +1,630 @@
+// Code generated by ModelQ
+// STATISTICS.go contains model for the database table [information_schema.STATISTICS]
+
+package mysql
+
+import (
+	"encoding/gob"
+	"encoding/json"
+
+	"database/sql"
+	"github.com/mijia/modelq/gmq"
+	"strings"
+)
+
+type Statistics struct {
+	TableCatalog string `json:"TABLE_CATALOG"`
+	TableSchema  string `json:"TABLE_SCHEMA"`
+	TableName    string `json:"TABLE_NAME"`
+	NonUnique    int64  `json:"NON_UNIQUE"`
+	IndexSchema  string `json:"INDEX_SCHEMA"`
+	IndexName    string `json:"INDEX_NAME"`
+	SeqInIndex   int64  `json:"SEQ_IN_INDEX"`
+	ColumnName   string `json:"COLUMN_NAME"`
+	Collation    string `json:"COLLATION"`
+	Cardinality  int64  `json:"CARDINALITY"`
+	SubPart      int64  `json:"SUB_PART"`
+	Packed       string `json:"PACKED"`
+	Nullable     string `json:"NULLABLE"`
+	IndexType    string `json:"INDEX_TYPE"`
+	Comment      string `json:"COMMENT"`
+	IndexComment string `json:"INDEX_COMMENT"`
+}
+
+// Start of the Statistics APIs.
+
+func (obj Statistics) String() string {
+	if data, err := json.Marshal(obj); err != nil {
+		return "<Statistics>"
+	} else {
+		return string(data)
+	}
+}
+
+func (obj Statistics) Get(dbtx gmq.DbTx) (Statistics, error) {
+	return obj, gmq.ErrNoPrimaryKeyDefined
+}
+
+func (obj Statistics) Insert(dbtx gmq.DbTx) (Statistics, error) {
+	_, err := StatisticsObjs.Insert(obj).Run(dbtx)
+	return obj, err
+}
+
+func (obj Statistics) Update(dbtx gmq.DbTx) (int64, error) {
+	return 0, gmq.ErrNoPrimaryKeyDefined
+}
+
+func (obj Statistics) Delete(dbtx gmq.DbTx) (int64, error) {
+	return 0, gmq.ErrNoPrimaryKeyDefined
+}
+
+// Start of the inner Query Api
+
+type _StatisticsQuery struct {
+	gmq.Query
+}
+
+func (q _StatisticsQuery) Where(f gmq.Filter) _StatisticsQuery {
+	q.Query = q.Query.Where(f)
+	return q
+}
+
+func (q _StatisticsQuery) OrderBy(by ...string) _StatisticsQuery {
+	tBy := make([]string, 0, len(by))
+	for _, b := range by {
+		sortDir := ""
+		if b[0] == '-' || b[0] == '+' {
+			sortDir = string(b[0])
+			b = b[1:]
+		}
+		if col, ok := StatisticsObjs.fcMap[b]; ok {
+			tBy = append(tBy, sortDir+col)
+		}
+	}
+	q.Query = q.Query.OrderBy(tBy...)
+	return q
+}
+
+func (q _StatisticsQuery) GroupBy(by ...string) _StatisticsQuery {
+	tBy := make([]string, 0, len(by))
+	for _, b := range by {
+		if col, ok := StatisticsObjs.fcMap[b]; ok {
+			tBy = append(tBy, col)
+		}
+	}
+	q.Query = q.Query.GroupBy(tBy...)
+	return q
+}
+
+func (q _StatisticsQuery) Limit(offsets ...int64) _StatisticsQuery {
+	q.Query = q.Query.Limit(offsets...)
+	return q
+}
+
+func (q _StatisticsQuery) Page(number, size int) _StatisticsQuery {
+	q.Query = q.Query.Page(number, size)
+	return q
+}
+
+func (q _StatisticsQuery) Run(dbtx gmq.DbTx) (sql.Result, error) {
+	return q.Query.Exec(dbtx)
+}
+
+type StatisticsRowVisitor func(obj Statistics) bool
+
+func (q _StatisticsQuery) Iterate(dbtx gmq.DbTx, functor StatisticsRowVisitor) error {
+	return q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj := StatisticsObjs.toStatistics(columns, rb)
+		return functor(obj)
+	})
+}
+
+func (q _StatisticsQuery) One(dbtx gmq.DbTx) (Statistics, error) {
+	var obj Statistics
+	err := q.Query.SelectOne(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj = StatisticsObjs.toStatistics(columns, rb)
+		return true
+	})
+	return obj, err
+}
+
+func (q _StatisticsQuery) List(dbtx gmq.DbTx) ([]Statistics, error) {
+	result := make([]Statistics, 0, 10)
+	err := q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj := StatisticsObjs.toStatistics(columns, rb)
+		result = append(result, obj)
+		return true
+	})
+	return result, err
+}
+
+func (q _StatisticsQuery) Count(dbtx gmq.DbTx) (int, error) {
+	result := 0
+
+	err := q.Query.SelectCount(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		if len(columns) == len(rb) {
+			for i := range columns {
+				if "_count" == columns[i].Name {
+					result = gmq.AsInt(rb[i])
+
+					return true
+				}
+			}
+		}
+
+		return true
+	})
+
+	return result, err
+}
+
+// Start of the model facade Apis.
+
+type _StatisticsObjs struct {
+	fcMap map[string]string
+}
+
+func (o _StatisticsObjs) Names() (schema, tbl, alias string) {
+	return "information_schema", "STATISTICS", "Statistics"
+}
+
+func (o _StatisticsObjs) Select(fields ...string) _StatisticsQuery {
+	q := _StatisticsQuery{}
+	if len(fields) == 0 {
+		fields = []string{"TableCatalog", "TableSchema", "TableName", "NonUnique", "IndexSchema", "IndexName", "SeqInIndex", "ColumnName", "Collation", "Cardinality", "SubPart", "Packed", "Nullable", "IndexType", "Comment", "IndexComment"}
+	}
+	q.Query = gmq.Select(o, o.columns(fields...))
+	return q
+}
+
+func (o _StatisticsObjs) Insert(obj Statistics) _StatisticsQuery {
+	q := _StatisticsQuery{}
+	q.Query = gmq.Insert(o, o.columnsWithData(obj, "TableCatalog", "TableSchema", "TableName", "NonUnique", "IndexSchema", "IndexName", "SeqInIndex", "ColumnName", "Collation", "Cardinality", "SubPart", "Packed", "Nullable", "IndexType", "Comment", "IndexComment"))
+	return q
+}
+
+func (o _StatisticsObjs) Update(obj Statistics, fields ...string) _StatisticsQuery {
+	q := _StatisticsQuery{}
+	q.Query = gmq.Update(o, o.columnsWithData(obj, fields...))
+	return q
+}
+
+func (o _StatisticsObjs) Delete() _StatisticsQuery {
+	q := _StatisticsQuery{}
+	q.Query = gmq.Delete(o)
+	return q
+}
+
+///// Managed Objects Filters definition
+
+func (o _StatisticsObjs) FilterTableCatalog(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("TABLE_CATALOG", op, params...)
+}
+
+func (o _StatisticsObjs) FilterTableSchema(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("TABLE_SCHEMA", op, params...)
+}
+
+func (o _StatisticsObjs) FilterTableName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("TABLE_NAME", op, params...)
+}
+
+func (o _StatisticsObjs) FilterNonUnique(op string, p int64, ps ...int64) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("NON_UNIQUE", op, params...)
+}
+
+func (o _StatisticsObjs) FilterIndexSchema(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("INDEX_SCHEMA", op, params...)
+}
+
+func (o _StatisticsObjs) FilterIndexName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("INDEX_NAME", op, params...)
+}
+
+func (o _StatisticsObjs) FilterSeqInIndex(op string, p int64, ps ...int64) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("SEQ_IN_INDEX", op, params...)
+}
+
+func (o _StatisticsObjs) FilterColumnName(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("COLUMN_NAME", op, params...)
+}
+
+func (o _StatisticsObjs) FilterCollation(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("COLLATION", op, params...)
+}
+
+func (o _StatisticsObjs) FilterCardinality(op string, p int64, ps ...int64) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("CARDINALITY", op, params...)
+}
+
+func (o _StatisticsObjs) FilterSubPart(op string, p int64, ps ...int64) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("SUB_PART", op, params...)
+}
+
+func (o _StatisticsObjs) FilterPacked(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("PACKED", op, params...)
+}
+
+func (o _StatisticsObjs) FilterNullable(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("NULLABLE", op, params...)
+}
+
+func (o _StatisticsObjs) FilterIndexType(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("INDEX_TYPE", op, params...)
+}
+
+func (o _StatisticsObjs) FilterComment(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("COMMENT", op, params...)
+}
+
+func (o _StatisticsObjs) FilterIndexComment(op string, p string, ps ...string) gmq.Filter {
+	params := make([]interface{}, 1+len(ps))
+	params[0] = p
+	for i := range ps {
+		params[i+1] = ps[i]
+	}
+	return o.newFilter("INDEX_COMMENT", op, params...)
+}
+
+///// Managed Objects Columns definition
+
+func (o _StatisticsObjs) ColumnTableCatalog(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"TABLE_CATALOG", value}
+}
+
+func (o _StatisticsObjs) ColumnTableSchema(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"TABLE_SCHEMA", value}
+}
+
+func (o _StatisticsObjs) ColumnTableName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"TABLE_NAME", value}
+}
+
+func (o _StatisticsObjs) ColumnNonUnique(p ...int64) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"NON_UNIQUE", value}
+}
+
+func (o _StatisticsObjs) ColumnIndexSchema(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"INDEX_SCHEMA", value}
+}
+
+func (o _StatisticsObjs) ColumnIndexName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"INDEX_NAME", value}
+}
+
+func (o _StatisticsObjs) ColumnSeqInIndex(p ...int64) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"SEQ_IN_INDEX", value}
+}
+
+func (o _StatisticsObjs) ColumnColumnName(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"COLUMN_NAME", value}
+}
+
+func (o _StatisticsObjs) ColumnCollation(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"COLLATION", value}
+}
+
+func (o _StatisticsObjs) ColumnCardinality(p ...int64) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"CARDINALITY", value}
+}
+
+func (o _StatisticsObjs) ColumnSubPart(p ...int64) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"SUB_PART", value}
+}
+
+func (o _StatisticsObjs) ColumnPacked(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"PACKED", value}
+}
+
+func (o _StatisticsObjs) ColumnNullable(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"NULLABLE", value}
+}
+
+func (o _StatisticsObjs) ColumnIndexType(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"INDEX_TYPE", value}
+}
+
+func (o _StatisticsObjs) ColumnComment(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"COMMENT", value}
+}
+
+func (o _StatisticsObjs) ColumnIndexComment(p ...string) gmq.Column {
+	var value interface{}
+	if len(p) > 0 {
+		value = p[0]
+	}
+	return gmq.Column{"INDEX_COMMENT", value}
+}
+
+////// Internal helper funcs
+
+func (o _StatisticsObjs) newFilter(name, op string, params ...interface{}) gmq.Filter {
+	if strings.ToUpper(op) == "IN" {
+		return gmq.InFilter(name, params)
+	}
+	return gmq.UnitFilter(name, op, params[0])
+}
+
+func (o _StatisticsObjs) toStatistics(columns []gmq.Column, rb []sql.RawBytes) Statistics {
+	obj := Statistics{}
+	if len(columns) == len(rb) {
+		for i := range columns {
+			switch columns[i].Name {
+			case "TABLE_CATALOG":
+				obj.TableCatalog = gmq.AsString(rb[i])
+			case "TABLE_SCHEMA":
+				obj.TableSchema = gmq.AsString(rb[i])
+			case "TABLE_NAME":
+				obj.TableName = gmq.AsString(rb[i])
+			case "NON_UNIQUE":
+				obj.NonUnique = gmq.AsInt64(rb[i])
+			case "INDEX_SCHEMA":
+				obj.IndexSchema = gmq.AsString(rb[i])
+			case "INDEX_NAME":
+				obj.IndexName = gmq.AsString(rb[i])
+			case "SEQ_IN_INDEX":
+				obj.SeqInIndex = gmq.AsInt64(rb[i])
+			case "COLUMN_NAME":
+				obj.ColumnName = gmq.AsString(rb[i])
+			case "COLLATION":
+				obj.Collation = gmq.AsString(rb[i])
+			case "CARDINALITY":
+				obj.Cardinality = gmq.AsInt64(rb[i])
+			case "SUB_PART":
+				obj.SubPart = gmq.AsInt64(rb[i])
+			case "PACKED":
+				obj.Packed = gmq.AsString(rb[i])
+			case "NULLABLE":
+				obj.Nullable = gmq.AsString(rb[i])
+			case "INDEX_TYPE":
+				obj.IndexType = gmq.AsString(rb[i])
+			case "COMMENT":
+				obj.Comment = gmq.AsString(rb[i])
+			case "INDEX_COMMENT":
+				obj.IndexComment = gmq.AsString(rb[i])
+			}
+		}
+	}
+	return obj
+}
+
+func (o _StatisticsObjs) columns(fields ...string) []gmq.Column {
+	data := make([]gmq.Column, 0, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "TableCatalog":
+			data = append(data, o.ColumnTableCatalog())
+		case "TableSchema":
+			data = append(data, o.ColumnTableSchema())
+		case "TableName":
+			data = append(data, o.ColumnTableName())
+		case "NonUnique":
+			data = append(data, o.ColumnNonUnique())
+		case "IndexSchema":
+			data = append(data, o.ColumnIndexSchema())
+		case "IndexName":
+			data = append(data, o.ColumnIndexName())
+		case "SeqInIndex":
+			data = append(data, o.ColumnSeqInIndex())
+		case "ColumnName":
+			data = append(data, o.ColumnColumnName())
+		case "Collation":
+			data = append(data, o.ColumnCollation())
+		case "Cardinality":
+			data = append(data, o.ColumnCardinality())
+		case "SubPart":
+			data = append(data, o.ColumnSubPart())
+		case "Packed":
+			data = append(data, o.ColumnPacked())
+		case "Nullable":
+			data = append(data, o.ColumnNullable())
+		case "IndexType":
+			data = append(data, o.ColumnIndexType())
+		case "Comment":
+			data = append(data, o.ColumnComment())
+		case "IndexComment":
+			data = append(data, o.ColumnIndexComment())
+		}
+	}
+	return data
+}
+
+func (o _StatisticsObjs) columnsWithData(obj Statistics, fields ...string) []gmq.Column {
+	data := make([]gmq.Column, 0, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "TableCatalog":
+			data = append(data, o.ColumnTableCatalog(obj.TableCatalog))
+		case "TableSchema":
+			data = append(data, o.ColumnTableSchema(obj.TableSchema))
+		case "TableName":
+			data = append(data, o.ColumnTableName(obj.TableName))
+		case "NonUnique":
+			data = append(data, o.ColumnNonUnique(obj.NonUnique))
+		case "IndexSchema":
+			data = append(data, o.ColumnIndexSchema(obj.IndexSchema))
+		case "IndexName":
+			data = append(data, o.ColumnIndexName(obj.IndexName))
+		case "SeqInIndex":
+			data = append(data, o.ColumnSeqInIndex(obj.SeqInIndex))
+		case "ColumnName":
+			data = append(data, o.ColumnColumnName(obj.ColumnName))
+		case "Collation":
+			data = append(data, o.ColumnCollation(obj.Collation))
+		case "Cardinality":
+			data = append(data, o.ColumnCardinality(obj.Cardinality))
+		case "SubPart":
+			data = append(data, o.ColumnSubPart(obj.SubPart))
+		case "Packed":
+			data = append(data, o.ColumnPacked(obj.Packed))
+		case "Nullable":
+			data = append(data, o.ColumnNullable(obj.Nullable))
+		case "IndexType":
+			data = append(data, o.ColumnIndexType(obj.IndexType))
+		case "Comment":
+			data = append(data, o.ColumnComment(obj.Comment))
+		case "IndexComment":
+			data = append(data, o.ColumnIndexComment(obj.IndexComment))
+		}
+	}
+	return data
+}
+
+var StatisticsObjs _StatisticsObjs
+
+func init() {
+	StatisticsObjs.fcMap = map[string]string{
+		"TableCatalog": "TABLE_CATALOG",
+		"TableSchema":  "TABLE_SCHEMA",
+		"TableName":    "TABLE_NAME",
+		"NonUnique":    "NON_UNIQUE",
+		"IndexSchema":  "INDEX_SCHEMA",
+		"IndexName":    "INDEX_NAME",
+		"SeqInIndex":   "SEQ_IN_INDEX",
+		"ColumnName":   "COLUMN_NAME",
+		"Collation":    "COLLATION",
+		"Cardinality":  "CARDINALITY",
+		"SubPart":      "SUB_PART",
+		"Packed":       "PACKED",
+		"Nullable":     "NULLABLE",
+		"IndexType":    "INDEX_TYPE",
+		"Comment":      "COMMENT",
+		"IndexComment": "INDEX_COMMENT",
+	}
+	gob.Register(Statistics{})
+}
@@ -32,20 +32,47 @@ func (p PostgresDriver) LoadDatabaseSchema(dsnString, schema, tableNames string)
 	return dbSchema, nil
 }
 
-func (p PostgresDriver) dataType(dt string) string {
+// dataType maps the udt_name of the column into the go type, the array types are the udt names
+// with the "_" prefix, e.g. _int4 for integer[].
+func (p PostgresDriver) dataType(udtName string) string {
 	kFieldTypes := map[string]string{
-		"bigint":    "int64",
-		"int":       "int",
-		"integer":   "int",
-		"smallint":  "int",
-		"character": "string",
-		"text":      "string",
-		"timestamp": "time.Time",
-		"numeric":   "float64",
-		"boolean":   "bool",
-	}
-	dt = strings.Split(dt, " ")[0]
-	if fieldType, ok := kFieldTypes[strings.ToLower(dt)]; !ok {
+		"int8":        "int64",
+		"int4":        "int",
+		"int2":        "int",
+		"float8":      "float64",
+		"float4":      "float32",
+		"numeric":     "float64",
+		"bool":        "bool",
+		"varchar":     "string",
+		"bpchar":      "string",
+		"text":        "string",
+		"uuid":        "string",
+		"json":        "json.RawMessage",
+		"jsonb":       "json.RawMessage",
+		"bytea":       "[]byte",
+		"timestamp":   "time.Time",
+		"timestamptz": "time.Time",
+		"date":        "time.Time",
+		"time":        "time.Time",
+		"timetz":      "time.Time",
+	}
+	kArrayTypes := map[string]string{
+		"int8":    "[]int64",
+		"int4":    "[]int",
+		"int2":    "[]int",
+		"float8":  "[]float64",
+		"float4":  "[]float64",
+		"numeric": "[]float64",
+		"bool":    "[]bool",
+	}
+	udtName = strings.ToLower(udtName)
+	if strings.HasPrefix(udtName, "_") {
+		if fieldType, ok := kArrayTypes[udtName[1:]]; ok {
+			return fieldType
+		}
+		return "[]string"
+	}
+	if fieldType, ok := kFieldTypes[udtName]; !ok {
 		return "string"
 	} else {
 		return fieldType
@@ -91,11 +118,154 @@ func (p PostgresDriver) queryPrimaryKeys(db *gmq.Db, dbName string, tables strin
 	return pKeys, nil
 }
 
+func (p PostgresDriver) queryForeignKeys(db *gmq.Db, dbName string, tables string) (map[string]*ForeignKey, error) {
+	// The constraint names of postgres are only unique in the table, but the referential_constraints of the
+	// information_schema has no table names, so the foreign keys are loaded from the pg_constraint by the
+	// conrelid/confrelid, and the referenced table may not be in the tables.
+	fKeys := make(map[string]*ForeignKey)
+	rows, err := db.Query(`SELECT t.relname, c.conname, a.attname, rn.nspname, r.relname, ra.attname,
+			c.confdeltype, c.confupdtype
+		FROM pg_constraint c
+		JOIN pg_class t ON t.oid = c.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_class r ON r.oid = c.confrelid
+		JOIN pg_namespace rn ON rn.oid = r.relnamespace
+		CROSS JOIN LATERAL unnest(c.conkey, c.confkey) AS k(attnum, refnum)
+		JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+		JOIN pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = k.refnum
+		WHERE c.contype = 'f' AND n.nspname = $1`, dbName)
+	if err != nil {
+		return fKeys, err
+	}
+	defer rows.Close()
+
+	tableSet := make(StringSet)
+	if len(tables) > 0 {
+		for _, table := range strings.Split(tables, ",") {
+			tableSet[table] = struct{}{}
+		}
+	}
+	for rows.Next() {
+		var tableName, constraintName, columnName, refSchema, refTable, refColumn, onDelete, onUpdate string
+		if err := rows.Scan(&tableName, &constraintName, &columnName, &refSchema, &refTable, &refColumn,
+			&onDelete, &onUpdate); err != nil {
+			return fKeys, err
+		}
+		if _, ok := tableSet[tableName]; !ok && len(tableSet) > 0 {
+			continue
+		}
+		fKeys[fmt.Sprintf("%s.%s", tableName, columnName)] = &ForeignKey{
+			ConstraintName: constraintName,
+			RefSchema:      refSchema,
+			RefTable:       refTable,
+			RefColumn:      refColumn,
+			OnDelete:       p.foreignKeyRule(onDelete),
+			OnUpdate:       p.foreignKeyRule(onUpdate),
+		}
+	}
+	return fKeys, rows.Err()
+}
+
+// foreignKeyRule maps the confdeltype/confupdtype of the pg_constraint into the rule names
+// of the information_schema, e.g. "c" is CASCADE.
+func (p PostgresDriver) foreignKeyRule(action string) string {
+	kRules := map[string]string{
+		"a": "NO ACTION",
+		"r": "RESTRICT",
+		"c": "CASCADE",
+		"n": "SET NULL",
+		"d": "SET DEFAULT",
+	}
+	if rule, ok := kRules[action]; ok {
+		return rule
+	}
+	return "NO ACTION"
+}
+
+func (p PostgresDriver) queryIndexes(db *gmq.Db, dbName string) (map[string][]*Index, error) {
+	// The indexes are not in the information_schema, so they have to be loaded from the pg_catalog,
+	// and the expression parts of the index would have no attribute names. The partial unique index,
+	// e.g. WHERE deleted_at IS NULL, is not unique for all the rows, so it is loaded as a normal index.
+	indexes := make(map[string][]*Index)
+	rows, err := db.Query(`SELECT t.relname, i.relname, x.indisunique AND x.indpred IS NULL, x.indisprimary,
+			COALESCE(a.attname, '')
+		FROM pg_index x
+		JOIN pg_class t ON t.oid = x.indrelid
+		JOIN pg_class i ON i.oid = x.indexrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		CROSS JOIN LATERAL unnest(x.indkey::smallint[]) WITH ORDINALITY AS k(attnum, seq)
+		LEFT JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+		WHERE n.nspname = $1
+		ORDER BY t.relname, i.relname, k.seq`, dbName)
+	if err != nil {
+		return indexes, err
+	}
+	defer rows.Close()
+
+	tableIndexes := make(map[string]*Index)
+	for rows.Next() {
+		var tableName, indexName, columnName string
+		var isUnique, isPrimary bool
+		if err := rows.Scan(&tableName, &indexName, &isUnique, &isPrimary, &columnName); err != nil {
+			return indexes, err
+		}
+		key := fmt.Sprintf("%s.%s", tableName, indexName)
+		index, ok := tableIndexes[key]
+		if !ok {
+			index = &Index{Name: indexName, Columns: make([]string, 0, 2), IsUnique: isUnique, IsPrimary: isPrimary}
+			tableIndexes[key] = index
+			indexes[tableName] = append(indexes[tableName], index)
+		}
+		index.Columns = append(index.Columns, columnName)
+	}
+	return indexes, rows.Err()
+}
+
+func (p PostgresDriver) queryEnums(db *gmq.Db, dbName string) (map[string][]string, error) {
+	enums := make(map[string][]string)
+	rows, err := db.Query(`SELECT t.typname, e.enumlabel
+		FROM pg_enum e
+		JOIN pg_type t ON t.oid = e.enumtypid
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE n.nspname = $1
+		ORDER BY t.typname, e.enumsortorder`, dbName)
+	if err != nil {
+		return enums, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var typeName, label string
+		if err := rows.Scan(&typeName, &label); err != nil {
+			return enums, err
+		}
+		enums[typeName] = append(enums[typeName], label)
+	}
+	return enums, rows.Err()
+}
+
 func (p PostgresDriver) queryColumns(db *gmq.Db, dbName string, tables string, dbSchema DbSchema) error {
+	tableFilter, err := NewTableFilter(tables)
+	if err != nil {
+		return err
+	}
+	tables = tableFilter.Names()
 	pKeys, err := p.queryPrimaryKeys(db, dbName, tables)
 	if err != nil {
 		return err
 	}
+	fKeys, err := p.queryForeignKeys(db, dbName, tables)
+	if err != nil {
+		return err
+	}
+	indexes, err := p.queryIndexes(db, dbName)
+	if err != nil {
+		return err
+	}
+	enums, err := p.queryEnums(db, dbName)
+	if err != nil {
+		return err
+	}
 
 	objs := postgres.ColumnsObjs
 	filter := objs.FilterTableSchema("=", dbName)
@@ -105,7 +275,10 @@ func (p PostgresDriver) queryColumns(db *gmq.Db, dbName string, tables string, d
 	}
 
 	query := objs.Select().Where(filter).OrderBy("TableName", "OrdinalPosition")
-	return query.Iterate(db, func(col postgres.Columns) bool {
+	err = query.Iterate(db, func(col postgres.Columns) bool {
+		if !tableFilter.Match(col.TableName) {
+			return true
+		}
 		if _, ok := dbSchema[col.TableName]; !ok {
 			dbSchema[col.TableName] = make(TableSchema, 0, 5)
 		}
@@ -113,8 +286,9 @@ func (p PostgresDriver) queryColumns(db *gmq.Db, dbName string, tables string, d
 		if strings.HasPrefix(col.ColumnDefault, "nextval(") {
 			extra = "AUTO_INCREMENT"
 		}
-		columnKey := fmt.Sprintf("%s.%s", col.TableName, col.ColumnName)
-		if _, ok := pKeys[columnKey]; ok {
+		columnName := fmt.Sprintf("%s.%s", col.TableName, col.ColumnName)
+		columnKey := ""
+		if _, ok := pKeys[columnName]; ok {
 			columnKey = "PRI"
 		}
 		sCol := Column{
@@ -122,12 +296,30 @@ func (p PostgresDriver) queryColumns(db *gmq.Db, dbName string, tables string, d
 			TableName:    col.TableName,
 			ColumnName:   col.ColumnName,
 			DefaultValue: col.ColumnDefault,
-			DataType:     p.dataType(col.DataType),
+			DataType:     p.dataType(col.UdtName),
+			ColumnType:   col.DataType,
 			ColumnKey:    columnKey,
 			Extra:        extra,
 			IsNullable:   col.IsNullable,
+			ForeignKey:   fKeys[columnName],
+
+			NumericPrecision: col.NumericPrecision,
+			NumericScale:     col.NumericScale,
+			CharMaxLength:    col.CharacterMaximumLength,
+		}
+		if values, ok := enums[col.UdtName]; ok && col.DataType == "USER-DEFINED" {
+			sCol.EnumValues, sCol.EnumType = values, col.UdtName
 		}
 		dbSchema[col.TableName] = append(dbSchema[col.TableName], sCol)
 		return true
 	})
+	if err != nil {
+		return err
+	}
+
+	// The UNI and MUL column keys would be marked by the indexes
+	for tableName, tableSchema := range dbSchema {
+		tableSchema.setIndexes(indexes[tableName])
+	}
+	return nil
 }
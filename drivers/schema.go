@@ -2,23 +2,307 @@ package drivers
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 type Column struct {
-	Schema       string
-	TableName    string
-	ColumnName   string
-	DefaultValue string
-	DataType     string
-	ColumnType   string
-	ColumnKey    string
-	Extra        string
-	Comment      string
-	IsNullable   string
+	Schema           string
+	TableName        string
+	ColumnName       string
+	DefaultValue     string
+	DataType         string
+	ColumnType       string
+	ColumnKey        string
+	Extra            string
+	Comment          string
+	IsNullable       string
+	ForeignKey       *ForeignKey
+	Indexes          []*Index
+	EnumValues       []string
+	EnumType         string
+	NumericPrecision int
+	NumericScale     int
+	CharMaxLength    int
+}
+
+// IsDecimal tells if it is the exact numeric column, e.g. decimal(12,2) or numeric
+func (c Column) IsDecimal() bool {
+	typeName := strings.ToLower(strings.TrimSpace(c.ColumnType))
+	if pos := strings.IndexAny(typeName, "( "); pos >= 0 {
+		typeName = typeName[:pos]
+	}
+	switch typeName {
+	case "decimal", "numeric", "dec", "fixed":
+		return true
+	}
+	return false
+}
+
+// parseNumericArgs gets the precision and scale from the column type, e.g. decimal(12,2), the scale is 0
+// if only the precision is given, and both are 0 for the unconstrained numeric.
+func parseNumericArgs(columnType string) (precision, scale int) {
+	start, end := strings.Index(columnType, "("), strings.Index(columnType, ")")
+	if start < 0 || end < start {
+		return 0, 0
+	}
+	args := strings.Split(columnType[start+1:end], ",")
+	precision, _ = strconv.Atoi(strings.TrimSpace(args[0]))
+	if len(args) > 1 {
+		scale, _ = strconv.Atoi(strings.TrimSpace(args[1]))
+	}
+	return precision, scale
+}
+
+// ForeignKey is the reference from a column to the column of another table, the columns of
+// a composite foreign key would share the same ConstraintName.
+type ForeignKey struct {
+	ConstraintName string
+	RefSchema      string
+	RefTable       string
+	RefColumn      string
+	OnDelete       string
+	OnUpdate       string
+}
+
+// Index is the definition of a table index including the primary key, the Columns are in the order
+// of the index and the expression parts of an index would be the empty names.
+type Index struct {
+	Name      string
+	Columns   []string
+	IsUnique  bool
+	IsPrimary bool
 }
 
 type TableSchema []Column
+
+// Indexes collects the indexes from the columns, the primary key comes first then the others by name.
+func (ts TableSchema) Indexes() []Index {
+	seen := make(StringSet)
+	indexes := make([]Index, 0)
+	for _, col := range ts {
+		for _, index := range col.Indexes {
+			if _, ok := seen[index.Name]; !ok {
+				seen[index.Name] = struct{}{}
+				indexes = append(indexes, *index)
+			}
+		}
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		if indexes[i].IsPrimary != indexes[j].IsPrimary {
+			return indexes[i].IsPrimary
+		}
+		return indexes[i].Name < indexes[j].Name
+	})
+	return indexes
+}
+
+// setIndexes attaches the indexes to their columns and marks the column keys from them.
+func (ts TableSchema) setIndexes(indexes []*Index) {
+	keys := indexColumnKeys(indexes)
+	for i := range ts {
+		col := &ts[i]
+		if key := keys[strings.ToLower(col.ColumnName)]; columnKeyRanks[key] > columnKeyRanks[col.ColumnKey] {
+			col.ColumnKey = key
+		}
+		for _, index := range indexes {
+			for _, name := range index.Columns {
+				if strings.EqualFold(name, col.ColumnName) {
+					col.Indexes = append(col.Indexes, index)
+					break
+				}
+			}
+		}
+	}
+}
+
+var columnKeyRanks = map[string]int{"": 0, "MUL": 1, "UNI": 2, "PRI": 3}
+
+// indexColumnKeys works the same as the COLUMN_KEY from MySQL, only the first column of the index would be marked,
+// a PRI is for the primary key, UNI is for a single column unique index and all the others would be MUL.
+// The keys of the result are the lower case column names.
+func indexColumnKeys(indexes []*Index) map[string]string {
+	keys := make(map[string]string)
+	for _, index := range indexes {
+		if len(index.Columns) == 0 {
+			continue
+		}
+		if index.IsPrimary {
+			for _, col := range index.Columns {
+				keys[strings.ToLower(col)] = "PRI"
+			}
+			continue
+		}
+		key := "MUL"
+		if index.IsUnique && len(index.Columns) == 1 {
+			key = "UNI"
+		}
+		first := strings.ToLower(index.Columns[0])
+		if first == "" {
+			continue
+		}
+		if columnKeyRanks[key] > columnKeyRanks[keys[first]] {
+			keys[first] = key
+		}
+	}
+	return keys
+}
+
+func (ts TableSchema) ForeignKeys() []Column {
+	columns := make([]Column, 0)
+	for _, col := range ts {
+		if col.ForeignKey != nil {
+			columns = append(columns, col)
+		}
+	}
+	return columns
+}
+
+// parseEnumValues gets the values from the column type of MySQL, e.g. enum('draft','published'), and the
+// doubled quotes in the values are unescaped.
+func parseEnumValues(columnType string) []string {
+	start, end := strings.Index(columnType, "("), strings.LastIndex(columnType, ")")
+	if start < 0 || end < start {
+		return nil
+	}
+	values := make([]string, 0, 4)
+	value := make([]byte, 0, 16)
+	inQuotes := false
+	body := columnType[start+1 : end]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case inQuotes && c == '\\' && i+1 < len(body):
+			i++
+			value = append(value, body[i])
+		case inQuotes && c == '\'' && i+1 < len(body) && body[i+1] == '\'':
+			i++
+			value = append(value, c)
+		case c == '\'':
+			if inQuotes {
+				values = append(values, string(value))
+				value = value[:0]
+			}
+			inQuotes = !inQuotes
+		case inQuotes:
+			value = append(value, c)
+		}
+	}
+	return values
+}
+
+// TableFilter selects the tables by the rules separated by the commas, a rule is the table name, a glob like
+// "billing_*" or a regexp between the slashes like "/^log_[0-9]+$/", and the rules starting with "!" exclude
+// the tables, e.g. "!schema_migrations,!*_tmp". All the tables are included if there is no include rule.
+type TableFilter struct {
+	includes []tableRule
+	excludes []tableRule
+}
+
+type tableRule struct {
+	name    string
+	glob    string
+	pattern *regexp.Regexp
+}
+
+func (r tableRule) match(name string) bool {
+	switch {
+	case r.pattern != nil:
+		return r.pattern.MatchString(name)
+	case r.glob != "":
+		ok, _ := path.Match(r.glob, name)
+		return ok
+	}
+	return r.name == name
+}
+
+func NewTableFilter(tables string) (TableFilter, error) {
+	var filter TableFilter
+	for _, rule := range splitTableRules(tables) {
+		rule = strings.TrimSpace(rule)
+		exclude := strings.HasPrefix(rule, "!")
+		if rule = strings.TrimSpace(strings.TrimPrefix(rule, "!")); rule == "" {
+			continue
+		}
+		var tRule tableRule
+		switch {
+		case len(rule) > 1 && strings.HasPrefix(rule, "/") && strings.HasSuffix(rule, "/"):
+			pattern, err := regexp.Compile(rule[1 : len(rule)-1])
+			if err != nil {
+				return filter, fmt.Errorf("Invalid table regexp %s, %s", rule, err)
+			}
+			tRule.pattern = pattern
+		case strings.ContainsAny(rule, "*?["):
+			if _, err := path.Match(rule, ""); err != nil {
+				return filter, fmt.Errorf("Invalid table pattern %s, %s", rule, err)
+			}
+			tRule.glob = rule
+		default:
+			tRule.name = rule
+		}
+		if exclude {
+			filter.excludes = append(filter.excludes, tRule)
+		} else {
+			filter.includes = append(filter.includes, tRule)
+		}
+	}
+	return filter, nil
+}
+
+// splitTableRules splits the rules by the commas outside of the regexps, e.g. "/^log_[0-9]{2,3}$/,user"
+// is the regexp and the name, and the regexp ends at the slash before a comma or the end.
+func splitTableRules(tables string) []string {
+	rules := make([]string, 0, 5)
+	start, inRegexp := 0, false
+	for i := 0; i < len(tables); i++ {
+		switch c := tables[i]; {
+		case c == '/' && !inRegexp:
+			inRegexp = strings.Trim(tables[start:i], " !") == ""
+		case c == '/' && inRegexp:
+			rest := strings.TrimSpace(tables[i+1:])
+			inRegexp = rest != "" && !strings.HasPrefix(rest, ",")
+		case c == ',' && !inRegexp:
+			rules = append(rules, tables[start:i])
+			start = i + 1
+		}
+	}
+	return append(rules, tables[start:])
+}
+
+// Match tells if the table is included and not excluded
+func (tf TableFilter) Match(name string) bool {
+	for _, rule := range tf.excludes {
+		if rule.match(name) {
+			return false
+		}
+	}
+	if len(tf.includes) == 0 {
+		return true
+	}
+	for _, rule := range tf.includes {
+		if rule.match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns the included table names joined by the commas for the IN filter of the queries, it is empty
+// if there is any pattern to include the tables, then all the tables are queried and checked by Match.
+func (tf TableFilter) Names() string {
+	names := make([]string, 0, len(tf.includes))
+	for _, rule := range tf.includes {
+		if rule.name == "" {
+			return ""
+		}
+		names = append(names, rule.name)
+	}
+	return strings.Join(names, ",")
+}
+
 type DbSchema map[string]TableSchema
 
 type Driver interface {
@@ -33,11 +317,22 @@ func LoadDatabaseSchema(driver, dsnString, schema, tableNames string) (dbSchema
 	}
 }
 
+// LoadDdlSchema loads the table schemas from the ddl files without a live database, the dialect
+// would be the same as the driver name.
+func LoadDdlSchema(dialect, ddlFiles, schema, tableNames string) (DbSchema, error) {
+	dialect = strings.ToLower(dialect)
+	if _, ok := drivers[dialect]; !ok {
+		return nil, fmt.Errorf("Not supported ddl dialect %s", dialect)
+	}
+	return DdlDriver{Dialect: dialect}.LoadDatabaseSchema(ddlFiles, schema, tableNames)
+}
+
 var drivers map[string]Driver
 
 func init() {
 	drivers = map[string]Driver{
 		"mysql":    MysqlDriver{},
 		"postgres": PostgresDriver{},
+		"sqlite":   SqliteDriver{},
 	}
 }
@@ -0,0 +1,274 @@
+package drivers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/mijia/modelq/gmq"
+)
+
+type SqliteDriver struct{}
+
+func (s SqliteDriver) LoadDatabaseSchema(dsnString, schema, tableNames string) (DbSchema, error) {
+	log.Printf("[SQLite Driver] Start to load tables schema from database, %s, tables=%s", dsnString, tableNames)
+	db, err := gmq.Open("sqlite3", dsnString)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	if err = db.Ping(); err != nil {
+		return nil, err
+	}
+
+	dbSchema := make(DbSchema)
+	if err = s.queryColumns(db, schema, tableNames, dbSchema); err != nil {
+		return nil, err
+	}
+	log.Printf("[SQLite Driver] Loaded schema data of %d tables from database[%s]", len(dbSchema), dsnString)
+	return dbSchema, nil
+}
+
+func (s SqliteDriver) dataType(declType string) string {
+	kFieldTypes := map[string]string{
+		"bigint":    "int64",
+		"integer":   "int64",
+		"int":       "int",
+		"mediumint": "int",
+		"smallint":  "int",
+		"tinyint":   "int",
+		"char":      "string",
+		"varchar":   "string",
+		"text":      "string",
+		"clob":      "string",
+		"blob":      "[]byte",
+		"real":      "float64",
+		"double":    "float64",
+		"float":     "float64",
+		"numeric":   "float64",
+		"decimal":   "float64",
+		"boolean":   "bool",
+		"bool":      "bool",
+		"date":      "time.Time",
+		"datetime":  "time.Time",
+		"timestamp": "time.Time",
+	}
+	dt := strings.ToLower(strings.TrimSpace(declType))
+	if pos := strings.Index(dt, "("); pos >= 0 {
+		dt = strings.TrimSpace(dt[:pos])
+	}
+	if fieldType, ok := kFieldTypes[dt]; ok {
+		return fieldType
+	}
+
+	// Fall back to the SQLite type affinity rules, https://www.sqlite.org/datatype3.html
+	switch {
+	case strings.Contains(dt, "int"):
+		return "int64"
+	case strings.Contains(dt, "char"), strings.Contains(dt, "clob"), strings.Contains(dt, "text"):
+		return "string"
+	case strings.Contains(dt, "blob"):
+		return "[]byte"
+	case strings.Contains(dt, "real"), strings.Contains(dt, "floa"), strings.Contains(dt, "doub"):
+		return "float64"
+	}
+	return "string"
+}
+
+func (s SqliteDriver) queryTableNames(db *gmq.Db, tables string) ([]string, error) {
+	tableFilter, err := NewTableFilter(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make([]string, 0, 10)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		if tableFilter.Match(name) {
+			names = append(names, name)
+		}
+	}
+	return names, rows.Err()
+}
+
+// pragma runs the PRAGMA statement against the table and visits each row by the column names,
+// the columns of PRAGMA results are different between SQLite versions, so we don't scan them by position.
+func (s SqliteDriver) pragma(db *gmq.Db, pragma, arg string, functor func(row map[string]string) bool) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA %s(\"%s\")", pragma, strings.Replace(arg, "\"", "\"\"", -1)))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	vals := make([]sql.RawBytes, len(cols))
+	ints := make([]interface{}, len(cols))
+	for i := range ints {
+		ints[i] = &vals[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ints...); err != nil {
+			return err
+		}
+		row := make(map[string]string, len(cols))
+		for i, col := range cols {
+			row[strings.ToLower(col)] = gmq.AsString(vals[i])
+		}
+		if continued := functor(row); !continued {
+			break
+		}
+	}
+	return rows.Err()
+}
+
+// queryIndexes loads the indexes except the primary key, which is not in the index list for the ROWID tables,
+// so the primary key would be defined from the table info. The partial unique index is loaded as a normal index.
+func (s SqliteDriver) queryIndexes(db *gmq.Db, tableName string) ([]*Index, error) {
+	indexes := make([]*Index, 0)
+	err := s.pragma(db, "index_list", tableName, func(row map[string]string) bool {
+		if row["origin"] != "pk" {
+			isUnique := row["unique"] == "1" && row["partial"] != "1"
+			indexes = append(indexes, &Index{Name: row["name"], IsUnique: isUnique})
+		}
+		return true
+	})
+	if err != nil {
+		return indexes, err
+	}
+
+	for _, index := range indexes {
+		columns := make([]string, 0, 2)
+		err := s.pragma(db, "index_info", index.Name, func(row map[string]string) bool {
+			columns = append(columns, row["name"])
+			return true
+		})
+		if err != nil {
+			return indexes, err
+		}
+		index.Columns = columns
+	}
+	return indexes, nil
+}
+
+func (s SqliteDriver) queryForeignKeys(db *gmq.Db, dbName string, tableName string) (map[string]*ForeignKey, error) {
+	fKeys := make(map[string]*ForeignKey)
+	err := s.pragma(db, "foreign_key_list", tableName, func(row map[string]string) bool {
+		fKeys[row["from"]] = &ForeignKey{
+			ConstraintName: fmt.Sprintf("fk_%s_%s", tableName, row["id"]),
+			RefSchema:      dbName,
+			RefTable:       row["table"],
+			RefColumn:      row["to"],
+			OnDelete:       row["on_delete"],
+			OnUpdate:       row["on_update"],
+		}
+		return true
+	})
+	if err != nil {
+		return fKeys, err
+	}
+
+	// The referenced column would be the primary key if it is omitted in the REFERENCES clause
+	for _, fKey := range fKeys {
+		if fKey.RefColumn != "" {
+			continue
+		}
+		err := s.pragma(db, "table_info", fKey.RefTable, func(row map[string]string) bool {
+			if row["pk"] == "1" {
+				fKey.RefColumn = row["name"]
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			return fKeys, err
+		}
+	}
+	return fKeys, nil
+}
+
+func (s SqliteDriver) queryColumns(db *gmq.Db, dbName string, tables string, dbSchema DbSchema) error {
+	tableNames, err := s.queryTableNames(db, tables)
+	if err != nil {
+		return err
+	}
+
+	for _, tableName := range tableNames {
+		indexes, err := s.queryIndexes(db, tableName)
+		if err != nil {
+			return err
+		}
+		fKeys, err := s.queryForeignKeys(db, dbName, tableName)
+		if err != nil {
+			return err
+		}
+
+		pkColumns := make(map[int]string)
+		tableSchema := make(TableSchema, 0, 5)
+		err = s.pragma(db, "table_info", tableName, func(row map[string]string) bool {
+			isNullable := "YES"
+			if row["notnull"] == "1" {
+				isNullable = "NO"
+			}
+			if pk, _ := strconv.Atoi(row["pk"]); pk > 0 {
+				isNullable = "NO"
+				pkColumns[pk] = row["name"]
+			}
+			sCol := Column{
+				Schema:       dbName,
+				TableName:    tableName,
+				ColumnName:   row["name"],
+				DefaultValue: strings.Trim(row["dflt_value"], "'"),
+				DataType:     s.dataType(row["type"]),
+				ColumnType:   strings.ToLower(row["type"]),
+				IsNullable:   isNullable,
+				ForeignKey:   fKeys[row["name"]],
+			}
+			if sCol.IsDecimal() {
+				sCol.NumericPrecision, sCol.NumericScale = parseNumericArgs(sCol.ColumnType)
+			}
+			if sCol.DataType == "string" {
+				sCol.CharMaxLength, _ = parseNumericArgs(sCol.ColumnType)
+			}
+			tableSchema = append(tableSchema, sCol)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(pkColumns) > 0 {
+			primary := &Index{Name: "PRIMARY", Columns: make([]string, len(pkColumns)), IsUnique: true, IsPrimary: true}
+			for pk, name := range pkColumns {
+				primary.Columns[pk-1] = name
+			}
+			indexes = append([]*Index{primary}, indexes...)
+		}
+		tableSchema.setIndexes(indexes)
+
+		// A single "INTEGER PRIMARY KEY" column is an alias for the ROWID which would be auto increased
+		if len(pkColumns) == 1 {
+			for i, col := range tableSchema {
+				if col.ColumnKey == "PRI" && col.ColumnType == "integer" {
+					tableSchema[i].Extra = "AUTO_INCREMENT"
+				}
+			}
+		}
+		dbSchema[tableName] = tableSchema
+	}
+	return nil
+}
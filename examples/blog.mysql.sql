@@ -27,6 +27,7 @@ CREATE TABLE IF NOT EXISTS `article` (
     `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE current_timestamp,
     PRIMARY KEY (`id`),
+    UNIQUE INDEX `UNIQUE_INDEX_article_user_title` (`user_id`, `title`),
     FOREIGN KEY (`user_id`) REFERENCES user(id) ON DELETE CASCADE
 ) ENGINE = InnoDB;
 
@@ -27,5 +27,6 @@ CREATE TABLE "article" (
 );
 
 CREATE INDEX "INDEX_article_state" ON "article" ("state");
+CREATE UNIQUE INDEX "UNIQUE_INDEX_article_user_title" ON "article" ("user_id", "title");
 
 commit;
\ No newline at end of file
@@ -0,0 +1,42 @@
+begin;
+
+DROP TABLE IF EXISTS "article";
+DROP TABLE IF EXISTS "user";
+DROP TABLE IF EXISTS "comment";
+
+CREATE TABLE IF NOT EXISTS "user" (
+    "id" INTEGER PRIMARY KEY,
+    "name" VARCHAR(50) NOT NULL,
+    "password" VARCHAR(50) NOT NULL,
+    "is_married" BOOLEAN DEFAULT NULL,
+    "age" INT DEFAULT NULL,
+    "create_time" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "update_time" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX "INDEX_user_age" ON "user" ("age" ASC);
+CREATE UNIQUE INDEX "UNIQUE_INDEX_user_name" ON "user" ("name" ASC);
+
+CREATE TABLE IF NOT EXISTS "article" (
+    "id" INTEGER PRIMARY KEY,
+    "user_id" BIGINT NOT NULL REFERENCES "user" ("id") ON DELETE CASCADE,
+    "title" VARCHAR(512) NOT NULL,
+    "state" TINYINT NOT NULL DEFAULT 0, -- "0: published, 1: draft, 2: hidden"
+    "content" TEXT DEFAULT NULL,
+    "donation" DECIMAL(12, 2) DEFAULT 0.5,
+    "create_time" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "update_time" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX "UNIQUE_INDEX_article_user_title" ON "article" ("user_id", "title");
+
+CREATE TABLE IF NOT EXISTS "comment" (
+    "user_id" BIGINT NOT NULL,
+    "article_id" BIGINT NOT NULL,
+    "content" TEXT DEFAULT NULL,
+    "create_time" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "update_time" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("user_id", "article_id")
+);
+
+commit;
@@ -22,6 +22,8 @@ type Article struct {
 	Donation   float64   `json:"donation"`
 	CreateTime time.Time `json:"create_time"`
 	UpdateTime time.Time `json:"update_time"`
+
+	preloadedUser *User
 }
 
 // Start of the Article APIs.
@@ -34,6 +36,15 @@ func (obj Article) String() string {
 	}
 }
 
+func (obj Article) Get(dbtx gmq.DbTx) (Article, error) {
+	filter := ArticleObjs.FilterId("=", obj.Id)
+	if result, err := ArticleObjs.Select().Where(filter).One(dbtx); err != nil {
+		return obj, err
+	} else {
+		return result, nil
+	}
+}
+
 func (obj Article) Insert(dbtx gmq.DbTx) (Article, error) {
 	if result, err := ArticleObjs.Insert(obj).Run(dbtx); err != nil {
 		return obj, err
@@ -69,10 +80,25 @@ func (obj Article) Delete(dbtx gmq.DbTx) (int64, error) {
 	}
 }
 
+func (obj Article) User(dbtx gmq.DbTx) (User, error) {
+	if obj.preloadedUser != nil {
+		return *obj.preloadedUser, nil
+	}
+	return UserObjs.Select().Where(UserObjs.FilterId("=", obj.UserId)).One(dbtx)
+}
+
+func (obj Article) LoadedUser() (User, bool) {
+	if obj.preloadedUser != nil {
+		return *obj.preloadedUser, true
+	}
+	return User{}, false
+}
+
 // Start of the inner Query Api
 
 type _ArticleQuery struct {
 	gmq.Query
+	preloads []string
 }
 
 func (q _ArticleQuery) Where(f gmq.Filter) _ArticleQuery {
@@ -117,6 +143,13 @@ func (q _ArticleQuery) Page(number, size int) _ArticleQuery {
 	return q
 }
 
+// Preload loads the relations by their accessor names with one more query for each after the rows are selected.
+func (q _ArticleQuery) Preload(relations ...string) _ArticleQuery {
+	preloads := make([]string, 0, len(q.preloads)+len(relations))
+	q.preloads = append(append(preloads, q.preloads...), relations...)
+	return q
+}
+
 func (q _ArticleQuery) Run(dbtx gmq.DbTx) (sql.Result, error) {
 	return q.Query.Exec(dbtx)
 }
@@ -124,8 +157,21 @@ func (q _ArticleQuery) Run(dbtx gmq.DbTx) (sql.Result, error) {
 type ArticleRowVisitor func(obj Article) bool
 
 func (q _ArticleQuery) Iterate(dbtx gmq.DbTx, functor ArticleRowVisitor) error {
+	if len(q.preloads) > 0 {
+		// the relations can only be preloaded after all the rows are selected
+		result, err := q.List(dbtx)
+		if err != nil {
+			return err
+		}
+		for _, obj := range result {
+			if !functor(obj) {
+				break
+			}
+		}
+		return nil
+	}
 	return q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := ArticleObjs.toArticle(columns, rb)
+		obj := ArticleObjs.toArticle(dbtx, columns, rb)
 		return functor(obj)
 	})
 }
@@ -133,19 +179,47 @@ func (q _ArticleQuery) Iterate(dbtx gmq.DbTx, functor ArticleRowVisitor) error {
 func (q _ArticleQuery) One(dbtx gmq.DbTx) (Article, error) {
 	var obj Article
 	err := q.Query.SelectOne(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj = ArticleObjs.toArticle(columns, rb)
+		obj = ArticleObjs.toArticle(dbtx, columns, rb)
 		return true
 	})
+	if err == nil && len(q.preloads) > 0 {
+		objs := []Article{obj}
+		err = ArticleObjs.preload(dbtx, objs, q.preloads...)
+		obj = objs[0]
+	}
 	return obj, err
 }
 
 func (q _ArticleQuery) List(dbtx gmq.DbTx) ([]Article, error) {
 	result := make([]Article, 0, 10)
 	err := q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := ArticleObjs.toArticle(columns, rb)
+		obj := ArticleObjs.toArticle(dbtx, columns, rb)
 		result = append(result, obj)
 		return true
 	})
+	if err == nil && len(q.preloads) > 0 {
+		err = ArticleObjs.preload(dbtx, result, q.preloads...)
+	}
+	return result, err
+}
+
+func (q _ArticleQuery) Count(dbtx gmq.DbTx) (int, error) {
+	result := 0
+
+	err := q.Query.SelectCount(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		if len(columns) == len(rb) {
+			for i := range columns {
+				if "_count" == columns[i].Name {
+					result = gmq.AsInt(rb[i])
+
+					return true
+				}
+			}
+		}
+
+		return true
+	})
+
 	return result, err
 }
 
@@ -186,6 +260,12 @@ func (o _ArticleObjs) Delete() _ArticleQuery {
 	return q
 }
 
+func (o _ArticleObjs) GetByUserIdTitle(dbtx gmq.DbTx, userId int64, title string) (Article, error) {
+	filter := ArticleObjs.FilterUserId("=", userId)
+	filter = filter.And(ArticleObjs.FilterTitle("=", title))
+	return o.Select().Where(filter).One(dbtx)
+}
+
 ///// Managed Objects Filters definition
 
 func (o _ArticleObjs) FilterId(op string, p int64, ps ...int64) gmq.Filter {
@@ -335,7 +415,7 @@ func (o _ArticleObjs) newFilter(name, op string, params ...interface{}) gmq.Filt
 	return gmq.UnitFilter(name, op, params[0])
 }
 
-func (o _ArticleObjs) toArticle(columns []gmq.Column, rb []sql.RawBytes) Article {
+func (o _ArticleObjs) toArticle(dbtx gmq.DbTx, columns []gmq.Column, rb []sql.RawBytes) Article {
 	obj := Article{}
 	if len(columns) == len(rb) {
 		for i := range columns {
@@ -353,9 +433,9 @@ func (o _ArticleObjs) toArticle(columns []gmq.Column, rb []sql.RawBytes) Article
 			case "donation":
 				obj.Donation = gmq.AsFloat64(rb[i])
 			case "create_time":
-				obj.CreateTime = gmq.AsTime(rb[i])
+				obj.CreateTime = gmq.AsTimeIn(rb[i], gmq.LocationOf(dbtx))
 			case "update_time":
-				obj.UpdateTime = gmq.AsTime(rb[i])
+				obj.UpdateTime = gmq.AsTimeIn(rb[i], gmq.LocationOf(dbtx))
 			}
 		}
 	}
@@ -412,6 +492,48 @@ func (o _ArticleObjs) columnsWithData(obj Article, fields ...string) []gmq.Colum
 	return data
 }
 
+func (o _ArticleObjs) preload(dbtx gmq.DbTx, objs []Article, relations ...string) error {
+	for _, relation := range relations {
+		var err error
+		switch relation {
+		case "User":
+			err = o.preloadUser(dbtx, objs)
+		default:
+			err = gmq.ErrUnknownRelation
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o _ArticleObjs) preloadUser(dbtx gmq.DbTx, objs []Article) error {
+	keys := make(map[int64]bool)
+	params := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		if key := obj.UserId; !keys[key] {
+			keys[key] = true
+			params = append(params, key)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	refs, err := UserObjs.Select().Where(gmq.InFilter("id", params)).List(dbtx)
+	if err != nil {
+		return err
+	}
+	refMap := make(map[int64]*User, len(refs))
+	for i := range refs {
+		refMap[refs[i].Id] = &refs[i]
+	}
+	for i, obj := range objs {
+		objs[i].preloadedUser = refMap[obj.UserId]
+	}
+	return nil
+}
+
 var ArticleObjs _ArticleObjs
 
 func init() {
@@ -31,6 +31,16 @@ func (obj Comment) String() string {
 	}
 }
 
+func (obj Comment) Get(dbtx gmq.DbTx) (Comment, error) {
+	filter := CommentObjs.FilterUserId("=", obj.UserId)
+	filter = filter.And(CommentObjs.FilterArticleId("=", obj.ArticleId))
+	if result, err := CommentObjs.Select().Where(filter).One(dbtx); err != nil {
+		return obj, err
+	} else {
+		return result, nil
+	}
+}
+
 func (obj Comment) Insert(dbtx gmq.DbTx) (Comment, error) {
 	_, err := CommentObjs.Insert(obj).Run(dbtx)
 	return obj, err
@@ -113,7 +123,7 @@ type CommentRowVisitor func(obj Comment) bool
 
 func (q _CommentQuery) Iterate(dbtx gmq.DbTx, functor CommentRowVisitor) error {
 	return q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := CommentObjs.toComment(columns, rb)
+		obj := CommentObjs.toComment(dbtx, columns, rb)
 		return functor(obj)
 	})
 }
@@ -121,7 +131,7 @@ func (q _CommentQuery) Iterate(dbtx gmq.DbTx, functor CommentRowVisitor) error {
 func (q _CommentQuery) One(dbtx gmq.DbTx) (Comment, error) {
 	var obj Comment
 	err := q.Query.SelectOne(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj = CommentObjs.toComment(columns, rb)
+		obj = CommentObjs.toComment(dbtx, columns, rb)
 		return true
 	})
 	return obj, err
@@ -130,13 +140,33 @@ func (q _CommentQuery) One(dbtx gmq.DbTx) (Comment, error) {
 func (q _CommentQuery) List(dbtx gmq.DbTx) ([]Comment, error) {
 	result := make([]Comment, 0, 10)
 	err := q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := CommentObjs.toComment(columns, rb)
+		obj := CommentObjs.toComment(dbtx, columns, rb)
 		result = append(result, obj)
 		return true
 	})
 	return result, err
 }
 
+func (q _CommentQuery) Count(dbtx gmq.DbTx) (int, error) {
+	result := 0
+
+	err := q.Query.SelectCount(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		if len(columns) == len(rb) {
+			for i := range columns {
+				if "_count" == columns[i].Name {
+					result = gmq.AsInt(rb[i])
+
+					return true
+				}
+			}
+		}
+
+		return true
+	})
+
+	return result, err
+}
+
 // Start of the model facade Apis.
 
 type _CommentObjs struct {
@@ -272,7 +302,7 @@ func (o _CommentObjs) newFilter(name, op string, params ...interface{}) gmq.Filt
 	return gmq.UnitFilter(name, op, params[0])
 }
 
-func (o _CommentObjs) toComment(columns []gmq.Column, rb []sql.RawBytes) Comment {
+func (o _CommentObjs) toComment(dbtx gmq.DbTx, columns []gmq.Column, rb []sql.RawBytes) Comment {
 	obj := Comment{}
 	if len(columns) == len(rb) {
 		for i := range columns {
@@ -284,9 +314,9 @@ func (o _CommentObjs) toComment(columns []gmq.Column, rb []sql.RawBytes) Comment
 			case "content":
 				obj.Content = gmq.AsString(rb[i])
 			case "create_time":
-				obj.CreateTime = gmq.AsTime(rb[i])
+				obj.CreateTime = gmq.AsTimeIn(rb[i], gmq.LocationOf(dbtx))
 			case "update_time":
-				obj.UpdateTime = gmq.AsTime(rb[i])
+				obj.UpdateTime = gmq.AsTimeIn(rb[i], gmq.LocationOf(dbtx))
 			}
 		}
 	}
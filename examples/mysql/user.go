@@ -21,6 +21,8 @@ type User struct {
 	Age        int       `json:"age"`
 	CreateTime time.Time `json:"create_time"`
 	UpdateTime time.Time `json:"update_time"`
+
+	preloadedArticles *[]Article
 }
 
 // Start of the User APIs.
@@ -33,6 +35,15 @@ func (obj User) String() string {
 	}
 }
 
+func (obj User) Get(dbtx gmq.DbTx) (User, error) {
+	filter := UserObjs.FilterId("=", obj.Id)
+	if result, err := UserObjs.Select().Where(filter).One(dbtx); err != nil {
+		return obj, err
+	} else {
+		return result, nil
+	}
+}
+
 func (obj User) Insert(dbtx gmq.DbTx) (User, error) {
 	if result, err := UserObjs.Insert(obj).Run(dbtx); err != nil {
 		return obj, err
@@ -68,10 +79,22 @@ func (obj User) Delete(dbtx gmq.DbTx) (int64, error) {
 	}
 }
 
+func (obj User) Articles() _ArticleQuery {
+	return ArticleObjs.Select().Where(ArticleObjs.FilterUserId("=", obj.Id))
+}
+
+func (obj User) LoadedArticles() ([]Article, bool) {
+	if obj.preloadedArticles != nil {
+		return *obj.preloadedArticles, true
+	}
+	return nil, false
+}
+
 // Start of the inner Query Api
 
 type _UserQuery struct {
 	gmq.Query
+	preloads []string
 }
 
 func (q _UserQuery) Where(f gmq.Filter) _UserQuery {
@@ -116,6 +139,13 @@ func (q _UserQuery) Page(number, size int) _UserQuery {
 	return q
 }
 
+// Preload loads the relations by their accessor names with one more query for each after the rows are selected.
+func (q _UserQuery) Preload(relations ...string) _UserQuery {
+	preloads := make([]string, 0, len(q.preloads)+len(relations))
+	q.preloads = append(append(preloads, q.preloads...), relations...)
+	return q
+}
+
 func (q _UserQuery) Run(dbtx gmq.DbTx) (sql.Result, error) {
 	return q.Query.Exec(dbtx)
 }
@@ -123,8 +153,21 @@ func (q _UserQuery) Run(dbtx gmq.DbTx) (sql.Result, error) {
 type UserRowVisitor func(obj User) bool
 
 func (q _UserQuery) Iterate(dbtx gmq.DbTx, functor UserRowVisitor) error {
+	if len(q.preloads) > 0 {
+		// the relations can only be preloaded after all the rows are selected
+		result, err := q.List(dbtx)
+		if err != nil {
+			return err
+		}
+		for _, obj := range result {
+			if !functor(obj) {
+				break
+			}
+		}
+		return nil
+	}
 	return q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := UserObjs.toUser(columns, rb)
+		obj := UserObjs.toUser(dbtx, columns, rb)
 		return functor(obj)
 	})
 }
@@ -132,19 +175,47 @@ func (q _UserQuery) Iterate(dbtx gmq.DbTx, functor UserRowVisitor) error {
 func (q _UserQuery) One(dbtx gmq.DbTx) (User, error) {
 	var obj User
 	err := q.Query.SelectOne(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj = UserObjs.toUser(columns, rb)
+		obj = UserObjs.toUser(dbtx, columns, rb)
 		return true
 	})
+	if err == nil && len(q.preloads) > 0 {
+		objs := []User{obj}
+		err = UserObjs.preload(dbtx, objs, q.preloads...)
+		obj = objs[0]
+	}
 	return obj, err
 }
 
 func (q _UserQuery) List(dbtx gmq.DbTx) ([]User, error) {
 	result := make([]User, 0, 10)
 	err := q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := UserObjs.toUser(columns, rb)
+		obj := UserObjs.toUser(dbtx, columns, rb)
 		result = append(result, obj)
 		return true
 	})
+	if err == nil && len(q.preloads) > 0 {
+		err = UserObjs.preload(dbtx, result, q.preloads...)
+	}
+	return result, err
+}
+
+func (q _UserQuery) Count(dbtx gmq.DbTx) (int, error) {
+	result := 0
+
+	err := q.Query.SelectCount(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		if len(columns) == len(rb) {
+			for i := range columns {
+				if "_count" == columns[i].Name {
+					result = gmq.AsInt(rb[i])
+
+					return true
+				}
+			}
+		}
+
+		return true
+	})
+
 	return result, err
 }
 
@@ -185,6 +256,16 @@ func (o _UserObjs) Delete() _UserQuery {
 	return q
 }
 
+func (o _UserObjs) FindByAge(dbtx gmq.DbTx, age int) _UserQuery {
+	filter := UserObjs.FilterAge("=", age)
+	return o.Select().Where(filter)
+}
+
+func (o _UserObjs) GetByName(dbtx gmq.DbTx, name string) (User, error) {
+	filter := UserObjs.FilterName("=", name)
+	return o.Select().Where(filter).One(dbtx)
+}
+
 ///// Managed Objects Filters definition
 
 func (o _UserObjs) FilterId(op string, p int64, ps ...int64) gmq.Filter {
@@ -317,7 +398,7 @@ func (o _UserObjs) newFilter(name, op string, params ...interface{}) gmq.Filter
 	return gmq.UnitFilter(name, op, params[0])
 }
 
-func (o _UserObjs) toUser(columns []gmq.Column, rb []sql.RawBytes) User {
+func (o _UserObjs) toUser(dbtx gmq.DbTx, columns []gmq.Column, rb []sql.RawBytes) User {
 	obj := User{}
 	if len(columns) == len(rb) {
 		for i := range columns {
@@ -333,9 +414,9 @@ func (o _UserObjs) toUser(columns []gmq.Column, rb []sql.RawBytes) User {
 			case "age":
 				obj.Age = gmq.AsInt(rb[i])
 			case "create_time":
-				obj.CreateTime = gmq.AsTime(rb[i])
+				obj.CreateTime = gmq.AsTimeIn(rb[i], gmq.LocationOf(dbtx))
 			case "update_time":
-				obj.UpdateTime = gmq.AsTime(rb[i])
+				obj.UpdateTime = gmq.AsTimeIn(rb[i], gmq.LocationOf(dbtx))
 			}
 		}
 	}
@@ -388,6 +469,49 @@ func (o _UserObjs) columnsWithData(obj User, fields ...string) []gmq.Column {
 	return data
 }
 
+func (o _UserObjs) preload(dbtx gmq.DbTx, objs []User, relations ...string) error {
+	for _, relation := range relations {
+		var err error
+		switch relation {
+		case "Articles":
+			err = o.preloadArticles(dbtx, objs)
+		default:
+			err = gmq.ErrUnknownRelation
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o _UserObjs) preloadArticles(dbtx gmq.DbTx, objs []User) error {
+	keys := make(map[int64]bool)
+	params := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		if key := obj.Id; !keys[key] {
+			keys[key] = true
+			params = append(params, key)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	refs, err := ArticleObjs.Select().Where(gmq.InFilter("user_id", params)).List(dbtx)
+	if err != nil {
+		return err
+	}
+	groups := make(map[int64][]Article)
+	for _, ref := range refs {
+		groups[ref.UserId] = append(groups[ref.UserId], ref)
+	}
+	for i, obj := range objs {
+		group := append([]Article{}, groups[obj.Id]...)
+		objs[i].preloadedArticles = &group
+	}
+	return nil
+}
+
 var UserObjs _UserObjs
 
 func init() {
@@ -19,18 +19,29 @@ type Article struct {
 	State    int     `json:"state"`
 	Content  string  `json:"content"`
 	Donation float64 `json:"donation"`
+
+	preloadedUser *User
 }
 
 // Start of the Article APIs.
 
 func (obj Article) String() string {
 	if data, err := json.Marshal(obj); err != nil {
-		return fmt.Sprintf("<Article Id=%d>", obj.Id)
+		return fmt.Sprintf("<Article Id=%v>", obj.Id)
 	} else {
 		return string(data)
 	}
 }
 
+func (obj Article) Get(dbtx gmq.DbTx) (Article, error) {
+	filter := ArticleObjs.FilterId("=", obj.Id)
+	if result, err := ArticleObjs.Select().Where(filter).One(dbtx); err != nil {
+		return obj, err
+	} else {
+		return result, nil
+	}
+}
+
 func (obj Article) Insert(dbtx gmq.DbTx) (Article, error) {
 	if result, err := ArticleObjs.Insert(obj).Run(dbtx); err != nil {
 		return obj, err
@@ -66,10 +77,25 @@ func (obj Article) Delete(dbtx gmq.DbTx) (int64, error) {
 	}
 }
 
+func (obj Article) User(dbtx gmq.DbTx) (User, error) {
+	if obj.preloadedUser != nil {
+		return *obj.preloadedUser, nil
+	}
+	return UserObjs.Select().Where(UserObjs.FilterId("=", obj.UserId)).One(dbtx)
+}
+
+func (obj Article) LoadedUser() (User, bool) {
+	if obj.preloadedUser != nil {
+		return *obj.preloadedUser, true
+	}
+	return User{}, false
+}
+
 // Start of the inner Query Api
 
 type _ArticleQuery struct {
 	gmq.Query
+	preloads []string
 }
 
 func (q _ArticleQuery) Where(f gmq.Filter) _ArticleQuery {
@@ -114,6 +140,13 @@ func (q _ArticleQuery) Page(number, size int) _ArticleQuery {
 	return q
 }
 
+// Preload loads the relations by their accessor names with one more query for each after the rows are selected.
+func (q _ArticleQuery) Preload(relations ...string) _ArticleQuery {
+	preloads := make([]string, 0, len(q.preloads)+len(relations))
+	q.preloads = append(append(preloads, q.preloads...), relations...)
+	return q
+}
+
 func (q _ArticleQuery) Run(dbtx gmq.DbTx) (sql.Result, error) {
 	return q.Query.Exec(dbtx)
 }
@@ -121,8 +154,21 @@ func (q _ArticleQuery) Run(dbtx gmq.DbTx) (sql.Result, error) {
 type ArticleRowVisitor func(obj Article) bool
 
 func (q _ArticleQuery) Iterate(dbtx gmq.DbTx, functor ArticleRowVisitor) error {
+	if len(q.preloads) > 0 {
+		// the relations can only be preloaded after all the rows are selected
+		result, err := q.List(dbtx)
+		if err != nil {
+			return err
+		}
+		for _, obj := range result {
+			if !functor(obj) {
+				break
+			}
+		}
+		return nil
+	}
 	return q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := ArticleObjs.toArticle(columns, rb)
+		obj := ArticleObjs.toArticle(dbtx, columns, rb)
 		return functor(obj)
 	})
 }
@@ -130,19 +176,47 @@ func (q _ArticleQuery) Iterate(dbtx gmq.DbTx, functor ArticleRowVisitor) error {
 func (q _ArticleQuery) One(dbtx gmq.DbTx) (Article, error) {
 	var obj Article
 	err := q.Query.SelectOne(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj = ArticleObjs.toArticle(columns, rb)
+		obj = ArticleObjs.toArticle(dbtx, columns, rb)
 		return true
 	})
+	if err == nil && len(q.preloads) > 0 {
+		objs := []Article{obj}
+		err = ArticleObjs.preload(dbtx, objs, q.preloads...)
+		obj = objs[0]
+	}
 	return obj, err
 }
 
 func (q _ArticleQuery) List(dbtx gmq.DbTx) ([]Article, error) {
 	result := make([]Article, 0, 10)
 	err := q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := ArticleObjs.toArticle(columns, rb)
+		obj := ArticleObjs.toArticle(dbtx, columns, rb)
 		result = append(result, obj)
 		return true
 	})
+	if err == nil && len(q.preloads) > 0 {
+		err = ArticleObjs.preload(dbtx, result, q.preloads...)
+	}
+	return result, err
+}
+
+func (q _ArticleQuery) Count(dbtx gmq.DbTx) (int, error) {
+	result := 0
+
+	err := q.Query.SelectCount(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		if len(columns) == len(rb) {
+			for i := range columns {
+				if "_count" == columns[i].Name {
+					result = gmq.AsInt(rb[i])
+
+					return true
+				}
+			}
+		}
+
+		return true
+	})
+
 	return result, err
 }
 
@@ -183,6 +257,17 @@ func (o _ArticleObjs) Delete() _ArticleQuery {
 	return q
 }
 
+func (o _ArticleObjs) FindByState(dbtx gmq.DbTx, state int) _ArticleQuery {
+	filter := ArticleObjs.FilterState("=", state)
+	return o.Select().Where(filter)
+}
+
+func (o _ArticleObjs) GetByUserIdTitle(dbtx gmq.DbTx, userId int64, title string) (Article, error) {
+	filter := ArticleObjs.FilterUserId("=", userId)
+	filter = filter.And(ArticleObjs.FilterTitle("=", title))
+	return o.Select().Where(filter).One(dbtx)
+}
+
 ///// Managed Objects Filters definition
 
 func (o _ArticleObjs) FilterId(op string, p int64, ps ...int64) gmq.Filter {
@@ -298,7 +383,7 @@ func (o _ArticleObjs) newFilter(name, op string, params ...interface{}) gmq.Filt
 	return gmq.UnitFilter(name, op, params[0])
 }
 
-func (o _ArticleObjs) toArticle(columns []gmq.Column, rb []sql.RawBytes) Article {
+func (o _ArticleObjs) toArticle(dbtx gmq.DbTx, columns []gmq.Column, rb []sql.RawBytes) Article {
 	obj := Article{}
 	if len(columns) == len(rb) {
 		for i := range columns {
@@ -363,6 +448,48 @@ func (o _ArticleObjs) columnsWithData(obj Article, fields ...string) []gmq.Colum
 	return data
 }
 
+func (o _ArticleObjs) preload(dbtx gmq.DbTx, objs []Article, relations ...string) error {
+	for _, relation := range relations {
+		var err error
+		switch relation {
+		case "User":
+			err = o.preloadUser(dbtx, objs)
+		default:
+			err = gmq.ErrUnknownRelation
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o _ArticleObjs) preloadUser(dbtx gmq.DbTx, objs []Article) error {
+	keys := make(map[int64]bool)
+	params := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		if key := obj.UserId; !keys[key] {
+			keys[key] = true
+			params = append(params, key)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	refs, err := UserObjs.Select().Where(gmq.InFilter("id", params)).List(dbtx)
+	if err != nil {
+		return err
+	}
+	refMap := make(map[int64]*User, len(refs))
+	for i := range refs {
+		refMap[refs[i].Id] = &refs[i]
+	}
+	for i, obj := range objs {
+		objs[i].preloadedUser = refMap[obj.UserId]
+	}
+	return nil
+}
+
 var ArticleObjs _ArticleObjs
 
 func init() {
@@ -0,0 +1,182 @@
+package examples
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	models "github.com/mijia/modelq/examples/sqlite"
+	"github.com/mijia/modelq/gmq"
+)
+
+var litedb *gmq.Db
+
+func TestLiteModelIterator(t *testing.T) {
+	objs := models.UserObjs
+	err := objs.Select().Where(objs.FilterAge(">=", 36)).OrderBy("-Age").
+		Iterate(litedb, func(u models.User) bool {
+			if u.Age < 50 {
+				fmt.Println(u.Name, u.Age)
+			}
+			return true
+		})
+	if err != nil {
+		t.Errorf("Iterateor is not working, %s", err)
+	}
+}
+
+func TestLiteModelBatchApi(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	err := gmq.WithinTx(litedb, func(tx *gmq.Tx) error {
+		for i := 0; i < 5; i++ {
+			user := models.User{}
+			user.Name = fmt.Sprintf("mijia_%d_%d", time.Now().UnixNano(), rand.Int63())
+			user.Password = "123456789"
+			user.Age = rand.Intn(120) + 1
+			if _, err := user.Insert(tx); err != nil {
+				t.Errorf("Failed to insert test data for batch query, %s", user)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Failed to insert test data in transaction, %s", err)
+	}
+
+	objs := models.UserObjs
+	query := objs.Select().Where(objs.FilterName("LIKE", "mijia%"))
+	if users, err := query.List(litedb); err != nil || len(users) < 5 {
+		t.Errorf("Failed to list query, %s", err)
+	}
+
+	query = objs.Select("Id", "Age").Where(objs.FilterAge(">=", 10)).
+		OrderBy("Age", "+Id").Page(9, 5)
+	if _, err := query.List(litedb); err != nil {
+		t.Errorf("Failed to list query, %s", err)
+	}
+
+	query = objs.Select("Age").Where(objs.FilterAge("IN", 12, 13, 14)).
+		GroupBy("Age")
+	if _, err := query.List(litedb); err != nil {
+		t.Errorf("Failed to list query, %s", err)
+	}
+
+	data := models.User{Age: 19, IsMarried: true}
+	query = objs.Update(data, "Age", "IsMarried").Where(objs.FilterAge("=", data.Age-1))
+	if _, err := query.Run(litedb); err != nil {
+		t.Errorf("Failed to do batch update, %s", err)
+	}
+
+	query = objs.Delete().Where(objs.FilterAge(">", 70))
+	if _, err := query.Run(litedb); err != nil {
+		t.Errorf("Failed to do batch delete, %s", err)
+	}
+}
+
+func TestLiteModelInstanceApi(t *testing.T) {
+	var err error
+	user := models.User{}
+	user.Name = "mijia"
+	user.Password = "test12345"
+	user.Age = 15
+
+	if user, err = user.Insert(litedb); err != nil || user.Id == 0 {
+		t.Errorf("Insert is not working, %v", err)
+	}
+
+	userId := user.Id
+	objs := models.UserObjs
+	query := objs.Select().Where(objs.FilterId("=", userId))
+
+	if user, err = query.One(litedb); err != nil || user.Name != "mijia" {
+		t.Errorf("Select one is not working, %v", err)
+	}
+
+	if found, err := objs.GetByName(litedb, "mijia"); err != nil || found.Id != userId {
+		t.Errorf("Finder by the unique key is not working, %v", err)
+	}
+	if users, err := objs.FindByAge(litedb, 15).List(litedb); err != nil || len(users) == 0 {
+		t.Errorf("Finder by the index is not working, %v", err)
+	}
+
+	user.Age = 36
+	user.IsMarried = true
+	if affected, err := user.Update(litedb); err != nil || affected == 0 {
+		t.Errorf("Update is not working, %v", err)
+	}
+	if user, err = query.One(litedb); err != nil || user.Age != 36 || !user.IsMarried {
+		t.Errorf("Select one is not working, %v", err)
+	}
+
+	article := models.Article{
+		UserId: user.Id,
+		Title:  "Hello World",
+	}
+	if article, err = article.Insert(litedb); err != nil || article.Id == 0 {
+		t.Errorf("Insert is not working for article, %v", err)
+	}
+
+	if found, err := models.ArticleObjs.GetByUserIdTitle(litedb, user.Id, "Hello World"); err != nil || found.Id != article.Id {
+		t.Errorf("Finder by the composite unique key is not working, %v", err)
+	}
+	if author, err := article.User(litedb); err != nil || author.Id != user.Id {
+		t.Errorf("Relation to the user is not working for article, %v", err)
+	}
+	if articles, err := user.Articles().List(litedb); err != nil || len(articles) != 1 {
+		t.Errorf("Relation to the articles is not working for user, %v", err)
+	}
+
+	if articles, err := models.ArticleObjs.Select().Preload("User").List(litedb); err != nil || len(articles) == 0 {
+		t.Errorf("Preload is not working for articles, %v", err)
+	} else if author, ok := articles[0].LoadedUser(); !ok || author.Id != articles[0].UserId {
+		t.Errorf("Preload does not stitch the user onto the article")
+	}
+	if user, err := query.Preload("Articles").One(litedb); err != nil {
+		t.Errorf("Preload is not working for user, %v", err)
+	} else if articles, ok := user.LoadedArticles(); !ok || len(articles) != 1 {
+		t.Errorf("Preload does not stitch the articles onto the user")
+	}
+
+	comment := models.Comment{
+		UserId:    user.Id,
+		ArticleId: article.Id,
+	}
+	if comment, err = comment.Insert(litedb); err != nil {
+		t.Errorf("Fail to insert a comment, %v", err)
+	}
+	comment.Content = "Woow"
+	if affected, err := comment.Update(litedb); err != nil || affected == 0 {
+		t.Errorf("Fail to update a comment, %s", err)
+	}
+
+	if affected, err := user.Delete(litedb); err != nil || affected == 0 {
+		t.Errorf("Delete is not working, %v", err)
+	}
+}
+
+func init() {
+	dbFile := path.Join(os.TempDir(), fmt.Sprintf("modelq_blog_%d.db", os.Getpid()))
+	os.Remove(dbFile)
+
+	var err error
+	litedb, err = gmq.Open("sqlite3", dbFile)
+	if err != nil {
+		panic(err)
+	}
+	ddl, err := ioutil.ReadFile("blog.sqlite.sql")
+	if err != nil {
+		panic(err)
+	}
+	if _, err = litedb.Exec(string(ddl)); err != nil {
+		panic(err)
+	}
+	gmq.Debug = true
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+}
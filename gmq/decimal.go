@@ -0,0 +1,191 @@
+package gmq
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is the exact number for the DECIMAL/NUMERIC columns, which is the unscaled big integer and the scale,
+// e.g. 12.50 is 1250 with the scale 2. The zero Decimal is 0, and the Decimal should not be changed once created.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// MaxDecimalScale limits the exponent and the digits after the decimal point of the parsed decimal, since
+// the decimal from the client json could be like "1e30000000" which needs the huge big integer to rescale.
+const MaxDecimalScale = 4096
+
+func NewDecimal(unscaled int64, scale int) Decimal {
+	return Decimal{big.NewInt(unscaled), scale}
+}
+
+// NewDecimalFromString parses the decimal like "-12.50", "12" or "1.5e-3"
+func NewDecimalFromString(s string) (Decimal, error) {
+	value := strings.TrimSpace(s)
+	exponent := 0
+	if pos := strings.IndexAny(value, "eE"); pos >= 0 {
+		n, err := strconv.Atoi(value[pos+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("Cannot parse %q as the decimal value", s)
+		}
+		value, exponent = value[:pos], n
+	}
+	scale := 0
+	if pos := strings.Index(value, "."); pos >= 0 {
+		scale = len(value) - pos - 1
+		value = value[:pos] + value[pos+1:]
+	}
+	if exponent > MaxDecimalScale || exponent < -MaxDecimalScale || scale-exponent > MaxDecimalScale ||
+		scale-exponent < -MaxDecimalScale {
+		return Decimal{}, fmt.Errorf("The decimal %q is out of the scale limit %d", s, MaxDecimalScale)
+	}
+	unscaled, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("Cannot parse %q as the decimal value", s)
+	}
+	d := Decimal{unscaled, scale - exponent}
+	if d.scale < 0 {
+		return d.Rescale(0), nil
+	}
+	return d, nil
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+func (d Decimal) Scale() int { return d.scale }
+
+func (d Decimal) Sign() int { return d.int().Sign() }
+
+func (d Decimal) IsZero() bool { return d.Sign() == 0 }
+
+func (d Decimal) Rat() *big.Rat {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(d.int(), denom)
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// Rescale changes the digits after the decimal point, which would be rounded half away from zero
+func (d Decimal) Rescale(scale int) Decimal {
+	if scale == d.scale {
+		return d
+	}
+	if scale > d.scale {
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil)
+		return Decimal{new(big.Int).Mul(d.int(), factor), scale}
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale-scale)), nil)
+	quo, rem := new(big.Int).QuoRem(d.int(), factor, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(factor) >= 0 {
+		quo.Add(quo, big.NewInt(int64(d.Sign())))
+	}
+	return Decimal{quo, scale}
+}
+
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+	case []byte:
+		return d.scanString(string(v))
+	case string:
+		return d.scanString(v)
+	case int64:
+		*d = NewDecimal(v, 0)
+		return nil
+	case float64:
+		return d.scanString(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return fmt.Errorf("Cannot scan %T into the decimal value", src)
+}
+
+func (d *Decimal) scanString(s string) error {
+	value, err := NewDecimalFromString(s)
+	if err != nil {
+		return err
+	}
+	*d = value
+	return nil
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// MarshalJSON encodes the decimal as the json number to keep the digits
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	return d.scanString(value)
+}
+
+func (d Decimal) GobEncode() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) GobDecode(data []byte) error {
+	return d.scanString(string(data))
+}
+
+// AsDecimal converts the value of the DECIMAL(precision, scale) column, it is rescaled to the column scale
+// unless the precision is unknown, e.g. the unconstrained NUMERIC of PostgreSQL.
+func AsDecimal(rb sql.RawBytes, precision, scale int) Decimal {
+	d, _ := ParseDecimal(rb, precision, scale)
+	return d
+}
+
+func ParseDecimal(rb sql.RawBytes, precision, scale int) (Decimal, error) {
+	if rb == nil {
+		return Decimal{}, nil
+	}
+	d, err := NewDecimalFromString(string(rb))
+	if err != nil || precision <= 0 {
+		return d, err
+	}
+	d = d.Rescale(scale)
+	if digits := len(new(big.Int).Abs(d.int()).String()); digits > precision && !d.IsZero() {
+		return Decimal{}, fmt.Errorf("The decimal %s is out of the precision %d", d, precision)
+	}
+	return d, nil
+}
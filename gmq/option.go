@@ -1,132 +1,139 @@
 package gmq
 
 import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
 	"errors"
 	"time"
 )
 
-// Tried to use option type for nullable columns, but found that may be a problem for json encoding
-// Will not use this for now
-
 var (
 	ErrOptionIsNone = errors.New("Option is not valid.")
 )
 
-type Option interface {
-	IsDefined() bool
-}
-
-type OptionInt interface {
-	Option
-	Get() (int, error)
+// Option is the optional value for the nullable columns, the none value would be NULL in the database,
+// null in json and also kept by the gob encoding. The zero Option is none.
+type Option[T any] struct {
+	value T
+	valid bool
 }
 
-func SomeInt(n int) OptionInt { return _OptionInt{n, true} }
-func NoneInt() OptionInt      { return _OptionInt{0, false} }
+func Some[T any](v T) Option[T] { return Option[T]{v, true} }
+func None[T any]() Option[T]    { return Option[T]{} }
 
-type OptionInt64 interface {
-	Option
-	Get() (int64, error)
+// NewOption returns the some value if valid is true or the none, e.g. gmq.NewOption(gmq.AsInt(rb), rb != nil)
+func NewOption[T any](v T, valid bool) Option[T] {
+	if !valid {
+		return None[T]()
+	}
+	return Some(v)
 }
 
-func SomeInt64(n int64) OptionInt64 { return _OptionInt64{n, true} }
-func NoneInt64() OptionInt64        { return _OptionInt64{0, false} }
-
-type OptionString interface {
-	Option
-	Get() (string, error)
+func (o Option[T]) Get() (T, error) {
+	if o.IsDefined() {
+		return o.value, nil
+	} else {
+		var zero T
+		return zero, ErrOptionIsNone
+	}
 }
 
-func SomeString(n string) OptionString { return _OptionString{n, true} }
-func NoneString() OptionString         { return _OptionString{"", false} }
+func (o Option[T]) IsDefined() bool { return o.valid }
 
-type OptionTime interface {
-	Option
-	Get() (time.Time, error)
+func (o *Option[T]) Scan(src interface{}) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	o.value, o.valid = n.V, n.Valid
+	return nil
 }
 
-func SomeTime(n time.Time) OptionTime { return _OptionTime{n, true} }
-func NoneTime() OptionTime            { return _OptionTime{time.Now(), false} }
-
-type OptionFloat64 interface {
-	Option
-	Get() (float64, error)
+func (o Option[T]) Value() (driver.Value, error) {
+	return sql.Null[T]{V: o.value, Valid: o.valid}.Value()
 }
 
-func SomeFloat64(n float64) OptionFloat64 { return _OptionFloat64{n, true} }
-func NoneFloat64() OptionFloat64          { return _OptionFloat64{0, false} }
-
-type _OptionInt struct {
-	value int
-	valid bool
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if !o.valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
 }
 
-func (o _OptionInt) Get() (int, error) {
-	if o.IsDefined() {
-		return o.value, nil
-	} else {
-		return 0, ErrOptionIsNone
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*o = None[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
 	}
+	*o = Some(v)
+	return nil
 }
 
-func (o _OptionInt) IsDefined() bool { return o.valid }
-
-type _OptionInt64 struct {
-	value int64
-	valid bool
+// GobEncode encodes the none as the empty bytes
+func (o Option[T]) GobEncode() ([]byte, error) {
+	if !o.valid {
+		return []byte{}, nil
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(o.value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
-func (o _OptionInt64) Get() (int64, error) {
-	if o.IsDefined() {
-		return o.value, nil
-	} else {
-		return 0, ErrOptionIsNone
+func (o *Option[T]) GobDecode(data []byte) error {
+	if len(data) == 0 {
+		*o = None[T]()
+		return nil
 	}
+	var v T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
 }
 
-func (o _OptionInt64) IsDefined() bool { return o.valid }
-
-type _OptionFloat64 struct {
-	value float64
-	valid bool
+// OptionValue drops the ErrOptionIsNone of the Get, e.g. gmq.OptionValue(obj.Age.Get()),
+// the IsDefined should be checked first.
+func OptionValue[T any](v T, err error) T {
+	return v
 }
 
-func (o _OptionFloat64) Get() (float64, error) {
-	if o.IsDefined() {
-		return o.value, nil
-	} else {
-		return 0, ErrOptionIsNone
-	}
-}
+type OptionInt = Option[int]
 
-func (o _OptionFloat64) IsDefined() bool { return o.valid }
+func SomeInt(n int) OptionInt { return Some(n) }
+func NoneInt() OptionInt      { return None[int]() }
 
-type _OptionString struct {
-	value string
-	valid bool
-}
+type OptionInt64 = Option[int64]
 
-func (o _OptionString) Get() (string, error) {
-	if o.IsDefined() {
-		return o.value, nil
-	} else {
-		return "", ErrOptionIsNone
-	}
-}
+func SomeInt64(n int64) OptionInt64 { return Some(n) }
+func NoneInt64() OptionInt64        { return None[int64]() }
 
-func (o _OptionString) IsDefined() bool { return o.valid }
+type OptionString = Option[string]
 
-type _OptionTime struct {
-	value time.Time
-	valid bool
-}
+func SomeString(n string) OptionString { return Some(n) }
+func NoneString() OptionString         { return None[string]() }
 
-func (o _OptionTime) Get() (time.Time, error) {
-	if o.IsDefined() {
-		return o.value, nil
-	} else {
-		return time.Now(), ErrOptionIsNone
-	}
-}
+type OptionTime = Option[time.Time]
+
+func SomeTime(n time.Time) OptionTime { return Some(n) }
+func NoneTime() OptionTime            { return None[time.Time]() }
+
+type OptionFloat64 = Option[float64]
+
+func SomeFloat64(n float64) OptionFloat64 { return Some(n) }
+func NoneFloat64() OptionFloat64          { return None[float64]() }
 
-func (o _OptionTime) IsDefined() bool { return o.valid }
+func AsOptionInt(rb sql.RawBytes) OptionInt         { return NewOption(AsInt(rb), rb != nil) }
+func AsOptionInt64(rb sql.RawBytes) OptionInt64     { return NewOption(AsInt64(rb), rb != nil) }
+func AsOptionString(rb sql.RawBytes) OptionString   { return NewOption(AsString(rb), rb != nil) }
+func AsOptionTime(rb sql.RawBytes) OptionTime       { return NewOption(AsTime(rb), rb != nil) }
+func AsOptionFloat64(rb sql.RawBytes) OptionFloat64 { return NewOption(AsFloat64(rb), rb != nil) }
@@ -2,8 +2,12 @@ package gmq
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -13,39 +17,67 @@ var (
 	ErrNotEnoughColumns    = errors.New("Not enough columns data for Insert/Update.")
 	ErrMultipleRowReturned = errors.New("Multiple row returned, but suppose there is only one row.")
 	ErrNotDbTxObject       = errors.New("This is not a valid database/sql.Db or sql.Tx")
+	ErrUnknownRelation     = errors.New("Unknown relation to preload, it should be the name of the relation accessor.")
 )
 
 type Db struct {
 	*sql.DB
 	driverName string
+	location   *time.Location
 }
 
 type Tx struct {
 	*sql.Tx
 	driverName string
+	location   *time.Location
 }
 
 func (tx *Tx) DriverName() string {
 	return tx.driverName
 }
 
+func (tx *Tx) Location() *time.Location {
+	return tx.location
+}
+
 func (db *Db) DriverName() string {
 	return db.driverName
 }
 
+// Location is for the time values without the time zone, which should be the same with the connection,
+// e.g. the loc param of the mysql dsn, default to UTC.
+func (db *Db) Location() *time.Location {
+	return db.location
+}
+
+func (db *Db) SetLocation(loc *time.Location) {
+	db.location = loc
+}
+
 func (db *Db) Beginx() (*Tx, error) {
 	tx, err := db.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
-	return &Tx{Tx: tx, driverName: db.driverName}, err
+	return &Tx{Tx: tx, driverName: db.driverName, location: db.location}, err
 }
 
 func NewDb(db *sql.DB, driverName string) *Db {
 	return &Db{
 		DB:         db,
 		driverName: driverName,
+		location:   time.UTC,
+	}
+}
+
+// LocationOf returns the location of the gmq.Db or gmq.Tx, or UTC for the other DbTx
+func LocationOf(dbtx DbTx) *time.Location {
+	if l, ok := dbtx.(interface {
+		Location() *time.Location
+	}); ok && l.Location() != nil {
+		return l.Location()
 	}
+	return time.UTC
 }
 
 func Open(driverName, dataSourceName string) (*Db, error) {
@@ -129,13 +161,19 @@ func WithinTx(db *Db, functor WithinTxFunctor) error {
 	}
 }
 
+// The AsXxx converters return the zero values for NULL or the values cannot be parsed, and the ParseXxx
+// ones are for the strict mode which return the errors instead, but NULL is still the zero value.
+
 func AsBool(rb sql.RawBytes) bool {
-	if len(rb) > 0 {
-		if b, err := strconv.ParseBool(string(rb)); err == nil {
-			return b
-		}
+	b, _ := ParseBool(rb)
+	return b
+}
+
+func ParseBool(rb sql.RawBytes) (bool, error) {
+	if rb == nil {
+		return false, nil
 	}
-	return false
+	return strconv.ParseBool(string(rb))
 }
 
 func AsString(rb sql.RawBytes) string {
@@ -149,40 +187,348 @@ func AsInt(rb sql.RawBytes) int {
 	return int(AsInt64(rb))
 }
 
+func ParseInt(rb sql.RawBytes) (int, error) {
+	if rb == nil {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(string(rb), 10, 0)
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
 func AsInt64(rb sql.RawBytes) int64 {
-	if len(rb) > 0 {
-		if n, err := strconv.ParseInt(string(rb), 10, 64); err == nil {
-			return n
-		}
+	n, _ := ParseInt64(rb)
+	return n
+}
+
+func ParseInt64(rb sql.RawBytes) (int64, error) {
+	if rb == nil {
+		return 0, nil
 	}
-	return 0
+	n, err := strconv.ParseInt(string(rb), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
 }
 
 func AsFloat64(rb sql.RawBytes) float64 {
-	if len(rb) > 0 {
-		if n, err := strconv.ParseFloat(string(rb), 64); err == nil {
-			return n
-		}
+	n, _ := ParseFloat64(rb)
+	return n
+}
+
+func ParseFloat64(rb sql.RawBytes) (float64, error) {
+	if rb == nil {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(string(rb), 64)
+	if err != nil {
+		return 0, err
 	}
-	return 0
+	return n, nil
+}
+
+func AsUint32(rb sql.RawBytes) uint32 {
+	return uint32(AsUint64(rb))
+}
+
+func ParseUint32(rb sql.RawBytes) (uint32, error) {
+	if rb == nil {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(string(rb), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(n), nil
+}
+
+func AsUint64(rb sql.RawBytes) uint64 {
+	n, _ := ParseUint64(rb)
+	return n
+}
+
+func ParseUint64(rb sql.RawBytes) (uint64, error) {
+	if rb == nil {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(string(rb), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// AsBit converts the MySQL BIT value, which is the big endian binary
+func AsBit(rb sql.RawBytes) uint64 {
+	var n uint64
+	for _, b := range rb {
+		n = n<<8 | uint64(b)
+	}
+	return n
+}
+
+func AsFloat32(rb sql.RawBytes) float32 {
+	n, _ := ParseFloat32(rb)
+	return n
+}
+
+func ParseFloat32(rb sql.RawBytes) (float32, error) {
+	if rb == nil {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(string(rb), 32)
+	if err != nil {
+		return 0, err
+	}
+	return float32(n), nil
+}
+
+// The time.Time values from drivers like github.com/lib/pq or mysql with parseTime=true are formatted by RFC3339
+// into the RawBytes, otherwise they are the textual formats of the databases, e.g. 2015-06-01 10:20:30.123456
+// of DATETIME(6) or 2015-06-01 10:20:30.123+08 of timestamptz. The fractional seconds are optional.
+var kTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999Z07:00",
+	"15:04:05.999999999Z07",
+	"15:04:05.999999999",
 }
 
 func AsTime(rb sql.RawBytes) time.Time {
-	if t, err := time.Parse("2006-01-02 15:04:05", string(rb)); err == nil {
-		return t
-	} else if t, err := time.Parse("2006-01-02", string(rb)); err == nil {
-		return t
-	} else if t, err := time.Parse("15:04:05", string(rb)); err == nil {
-		return t
+	return AsTimeIn(rb, time.UTC)
+}
+
+// AsTimeIn converts the time value in the location, which is used for the values without the time zone,
+// and the ones with the offsets keep their own zones, e.g. gmq.AsTimeIn(rb, gmq.LocationOf(dbtx))
+func AsTimeIn(rb sql.RawBytes, loc *time.Location) time.Time {
+	t, _ := ParseTimeIn(rb, loc)
+	return t
+}
+
+func ParseTime(rb sql.RawBytes) (time.Time, error) {
+	return ParseTimeIn(rb, time.UTC)
+}
+
+func ParseTimeIn(rb sql.RawBytes, loc *time.Location) (time.Time, error) {
+	value := string(rb)
+	// the zero dates of MySQL are also the zero time
+	if rb == nil || strings.HasPrefix(value, "0000-00-00") {
+		return time.Time{}, nil
+	}
+	for _, layout := range kTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
 	}
+	return time.Time{}, fmt.Errorf("Cannot parse %q as the time value", value)
+}
 
-	return time.Time{}
+// AsDuration converts the TIME value of MySQL, which could be negative or out of 24 hours, e.g. -838:59:59.000000
+func AsDuration(rb sql.RawBytes) time.Duration {
+	d, _ := ParseDuration(rb)
+	return d
+}
+
+func ParseDuration(rb sql.RawBytes) (time.Duration, error) {
+	if rb == nil {
+		return 0, nil
+	}
+	value := string(rb)
+	sign := time.Duration(1)
+	if strings.HasPrefix(value, "-") {
+		sign, value = -1, value[1:]
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("Cannot parse %q as the TIME value", string(rb))
+	}
+	fraction := "0"
+	if pos := strings.Index(parts[2], "."); pos >= 0 {
+		parts[2], fraction = parts[2][:pos], (parts[2][pos+1:] + "000000000")[:9]
+	}
+	d := time.Duration(0)
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		n, err := strconv.ParseInt(parts[i], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * unit
+	}
+	nanos, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return sign * (d + time.Duration(nanos)), nil
+}
+
+// AsSet converts the MySQL SET value, the members are separated by commas
+func AsSet(rb sql.RawBytes) []string {
+	if rb == nil {
+		return nil
+	}
+	if len(rb) == 0 {
+		return []string{}
+	}
+	return strings.Split(string(rb), ",")
+}
+
+// AsScanner converts the value by the sql.Scanner of the type, e.g. gmq.AsScanner[decimal.Decimal](rb)
+func AsScanner[T any, PT interface {
+	*T
+	sql.Scanner
+}](rb sql.RawBytes) T {
+	v, _ := ParseScanner[T, PT](rb)
+	return v
+}
+
+func ParseScanner[T any, PT interface {
+	*T
+	sql.Scanner
+}](rb sql.RawBytes) (T, error) {
+	var v T
+	var src interface{}
+	if rb != nil {
+		// the RawBytes would be reused by the next row
+		src = append([]byte{}, rb...)
+	}
+	err := PT(&v).Scan(src)
+	return v, err
+}
+
+// Ptr returns the pointer of the value for the nullable fields, or nil if the value is not valid
+func Ptr[T any](v T, valid bool) *T {
+	if !valid {
+		return nil
+	}
+	return &v
 }
 
 func AsByteArray(rb sql.RawBytes) []byte {
        return []byte(rb)
 }
 
+func AsJson(rb sql.RawBytes) json.RawMessage {
+	if len(rb) > 0 {
+		return json.RawMessage(append([]byte{}, rb...))
+	}
+	return nil
+}
+
+// AsJsonOf unmarshals the json column into the user type, e.g. gmq.AsJsonOf[ArticleMeta](rb)
+func AsJsonOf[T any](rb sql.RawBytes) T {
+	v, _ := ParseJsonOf[T](rb)
+	return v
+}
+
+func ParseJsonOf[T any](rb sql.RawBytes) (T, error) {
+	var v T
+	if len(rb) == 0 {
+		return v, nil
+	}
+	err := json.Unmarshal(rb, &v)
+	return v, err
+}
+
+// JsonValue marshals the user type for the json column, and the nil would be NULL
+func JsonValue(v interface{}) driver.Valuer {
+	return jsonValue{v}
+}
+
+type jsonValue struct {
+	v interface{}
+}
+
+func (j jsonValue) Value() (driver.Value, error) {
+	data, err := json.Marshal(j.v)
+	if err != nil || string(data) == "null" {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Sensitive wraps the value of the sensitive column, e.g. the password, it is sent to the database as is
+// but printed as [REDACTED] in the debug logs of the SQL params.
+func Sensitive(v interface{}) interface{} {
+	return sensitiveValue{v}
+}
+
+type sensitiveValue struct {
+	v interface{}
+}
+
+func (s sensitiveValue) String() string { return "[REDACTED]" }
+
+// The AsXxxArray converters parse the array literals of PostgreSQL like {1,2,3} or {"a b",c,NULL},
+// the NULL elements would be the zero values.
+
+func AsStringArray(rb sql.RawBytes) []string {
+	values, _ := parseArray(rb, func(elem sql.RawBytes) (string, error) { return string(elem), nil })
+	return values
+}
+
+func AsIntArray(rb sql.RawBytes) []int {
+	values, _ := parseArray(rb, func(elem sql.RawBytes) (int, error) { return AsInt(elem), nil })
+	return values
+}
+
+func ParseIntArray(rb sql.RawBytes) ([]int, error) {
+	return parseArray(rb, ParseInt)
+}
+
+func AsInt64Array(rb sql.RawBytes) []int64 {
+	values, _ := parseArray(rb, func(elem sql.RawBytes) (int64, error) { return AsInt64(elem), nil })
+	return values
+}
+
+func ParseInt64Array(rb sql.RawBytes) ([]int64, error) {
+	return parseArray(rb, ParseInt64)
+}
+
+func AsFloat64Array(rb sql.RawBytes) []float64 {
+	values, _ := parseArray(rb, func(elem sql.RawBytes) (float64, error) { return AsFloat64(elem), nil })
+	return values
+}
+
+func ParseFloat64Array(rb sql.RawBytes) ([]float64, error) {
+	return parseArray(rb, ParseFloat64)
+}
+
+func AsBoolArray(rb sql.RawBytes) []bool {
+	values, _ := parseArray(rb, func(elem sql.RawBytes) (bool, error) { return AsBool(elem), nil })
+	return values
+}
+
+func ParseBoolArray(rb sql.RawBytes) ([]bool, error) {
+	return parseArray(rb, ParseBool)
+}
+
+func parseArray[T any](rb sql.RawBytes, parse func(sql.RawBytes) (T, error)) ([]T, error) {
+	elems := parseArrayLiteral(rb)
+	if elems == nil {
+		return nil, nil
+	}
+	values := make([]T, len(elems))
+	for i, elem := range elems {
+		if elem == nil {
+			continue
+		}
+		value, err := parse(sql.RawBytes(*elem))
+		if err != nil {
+			return values, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
 var Debug bool
 
 func init() {
@@ -116,7 +116,7 @@ func (q _Query) query(dbtx DbTx, query string, params []interface{}, functor Que
 		return err
 	} else {
 		defer stmt.Close()
-		if rows, err := stmt.Query(params...); err != nil {
+		if rows, err := stmt.Query(bindSqlParams(params, dbtx.DriverName())...); err != nil {
 			return err
 		} else {
 			defer rows.Close()
@@ -160,7 +160,7 @@ func (q _Query) exec(dbtx DbTx, query string, params []interface{}) (sql.Result,
 		return result, err
 	} else {
 		defer stmt.Close()
-		return stmt.Exec(params...)
+		return stmt.Exec(bindSqlParams(params, dbtx.DriverName())...)
 	}
 }
 
@@ -2,12 +2,22 @@ package gmq
 
 import (
 	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
+func isSqlite(driverName string) bool {
+	return driverName == "sqlite3" || driverName == "sqlite"
+}
+
 func dbQuote(name string, driverName string) string {
-	if driverName == "postgres" {
+	if driverName == "postgres" || isSqlite(driverName) {
 		return fmt.Sprintf("\"%s\"", name)
 	}
 	return fmt.Sprintf("`%s`", name)
@@ -64,3 +74,154 @@ func rebindSqlParams(query string, driverName string) string {
 	}
 	return query
 }
+
+// bindSqlParams converts the params which the database/sql doesn't accept, the json.RawMessage is sent
+// as a string, the time.Duration is for the TIME columns, and the slices would be the array literals
+// for postgres or the SET values for mysql. The pointers of the nullable fields are dereferenced first.
+// The sql.Null and Option of the durations and unsigned ints are unwrapped as well, since their Value()
+// goes through the default converter which doesn't support the uint64 with the high bit set.
+func bindSqlParams(params []interface{}, driverName string) []interface{} {
+	bound := make([]interface{}, len(params))
+	for i, param := range params {
+		if p, ok := param.(sensitiveValue); ok {
+			param = p.v
+		}
+		if _, ok := param.(driver.Valuer); !ok && param != nil {
+			if v := reflect.ValueOf(param); v.Kind() == reflect.Ptr {
+				param = nil
+				if !v.IsNil() {
+					param = v.Elem().Interface()
+				}
+			}
+		}
+		for _, unwrap := range nullableParams {
+			if v, ok := unwrap(param); ok {
+				param = v
+				break
+			}
+		}
+		bound[i] = param
+		switch p := param.(type) {
+		case json.RawMessage:
+			if p == nil {
+				bound[i] = nil
+			} else {
+				bound[i] = string(p)
+			}
+		case time.Duration:
+			bound[i] = formatDuration(p)
+		case []string:
+			if driverName == "postgres" {
+				bound[i] = formatArrayLiteral(p)
+			} else if driverName == "mysql" {
+				bound[i] = strings.Join(p, ",")
+			}
+		case []int, []int64, []float64, []bool:
+			if driverName == "postgres" {
+				bound[i] = formatArrayLiteral(p)
+			}
+		}
+	}
+	return bound
+}
+
+var nullableParams = []func(interface{}) (interface{}, bool){
+	unwrapNullable[time.Duration], unwrapNullable[uint32], unwrapNullable[uint64],
+}
+
+func unwrapNullable[T any](param interface{}) (interface{}, bool) {
+	switch p := param.(type) {
+	case sql.Null[T]:
+		if p.Valid {
+			return p.V, true
+		}
+		return nil, true
+	case Option[T]:
+		if v, err := p.Get(); err == nil {
+			return v, true
+		}
+		return nil, true
+	}
+	return param, false
+}
+
+func formatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	seconds := (d % time.Minute) / time.Second
+	micros := (d % time.Second) / time.Microsecond
+	return fmt.Sprintf("%s%02d:%02d:%02d.%06d", sign, hours, minutes, seconds, micros)
+}
+
+func formatArrayLiteral(slice interface{}) string {
+	elems := make([]string, 0)
+	switch s := slice.(type) {
+	case []string:
+		for _, v := range s {
+			v = strings.Replace(strings.Replace(v, "\\", "\\\\", -1), "\"", "\\\"", -1)
+			elems = append(elems, "\""+v+"\"")
+		}
+	case []int:
+		for _, v := range s {
+			elems = append(elems, strconv.Itoa(v))
+		}
+	case []int64:
+		for _, v := range s {
+			elems = append(elems, strconv.FormatInt(v, 10))
+		}
+	case []float64:
+		for _, v := range s {
+			elems = append(elems, strconv.FormatFloat(v, 'g', -1, 64))
+		}
+	case []bool:
+		for _, v := range s {
+			elems = append(elems, strconv.FormatBool(v))
+		}
+	}
+	return "{" + strings.Join(elems, ",") + "}"
+}
+
+// parseArrayLiteral splits the array literal into the elements, a nil element is a NULL and
+// the nested arrays would be flattened.
+func parseArrayLiteral(rb []byte) []*string {
+	if len(rb) == 0 {
+		return nil
+	}
+	elems := make([]*string, 0)
+	var elem []byte
+	inElem, quoted, inQuotes := false, false, false
+	flush := func() {
+		if inElem {
+			value := string(elem)
+			if !quoted && strings.EqualFold(value, "NULL") {
+				elems = append(elems, nil)
+			} else {
+				elems = append(elems, &value)
+			}
+		}
+		elem, inElem, quoted = elem[:0], false, false
+	}
+	for i := 0; i < len(rb); i++ {
+		c := rb[i]
+		switch {
+		case inQuotes && c == '\\' && i+1 < len(rb):
+			i++
+			elem = append(elem, rb[i])
+		case c == '"':
+			inQuotes, inElem, quoted = !inQuotes, true, true
+		case inQuotes:
+			elem = append(elem, c)
+		case c == '{' || c == '}' || c == ',':
+			flush()
+		case c == ' ' && !inElem:
+		default:
+			inElem = true
+			elem = append(elem, c)
+		}
+	}
+	return elems
+}
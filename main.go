@@ -7,25 +7,48 @@ import (
 	"log"
 	"os/exec"
 	"runtime"
+	"strings"
 
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/mijia/modelq/drivers"
 	"github.com/mijia/modelq/gmq"
 )
 
 func main() {
-	var targetDb, tableNames, packageName string
-	var tmplName string
-	var driver, schemaName string
-	var touchTimestamp bool
+	var targetDb, ddlFiles, tableNames, packageName string
+	var tmplName, configFile string
+	var driver, schemaName, nullable, decimal, jsonTypes, columnTypes, naming, initialisms, names string
+	var irregulars, stripPrefixes, stripSuffixes, tags, tagCase, sensitive string
+	var touchTimestamp, commentEnums, strict, singular, omitempty, validate bool
 	var pCount int
+	flag.StringVar(&configFile, "config", "", "Load the settings from the yaml or json file, default to modelq.yaml, modelq.yml or modelq.json in the current directory if exists")
 	flag.StringVar(&targetDb, "db", "", "Target database source string: e.g. root@tcp(127.0.0.1:3306)/test?charset=utf-8")
-	flag.StringVar(&tableNames, "tables", "", "You may specify which tables the models need to be created, e.g. \"user,article,blog\"")
+	flag.StringVar(&ddlFiles, "ddl", "", "Load the schema from the DDL files or directories instead of a live database, e.g. \"schema.sql,migrations\"")
+	flag.StringVar(&tableNames, "tables", "", "You may specify which tables the models need to be created by the names, globs or /regexps/, and exclude the ones by \"!\", e.g. \"user,billing_*,!*_tmp\"")
 	flag.StringVar(&packageName, "pkg", "", "Go source code package for generated models")
-	flag.StringVar(&driver, "driver", "mysql", "Current supported drivers include mysql, postgres")
-	flag.StringVar(&schemaName, "schema", "", "Schema for postgresql, database name for mysql")
+	flag.StringVar(&driver, "driver", "mysql", "Current supported drivers include mysql, postgres, sqlite")
+	flag.StringVar(&schemaName, "schema", "", "Schema for postgresql, database name for mysql, default to main for sqlite")
 	flag.BoolVar(&touchTimestamp, "dont-touch-timestamp", false, "Should touch the datetime fields with default value or on update")
+	flag.BoolVar(&commentEnums, "comment-enums", false, "Generate the typed constants for the int columns by the comments like \"0: published, 1: draft\"")
+	flag.BoolVar(&strict, "strict", false, "Return the errors from One/List/Iterate if the column values cannot be converted, instead of the zero values")
+	flag.StringVar(&decimal, "decimal", "", "Generate the decimal/numeric columns as gmq.Decimal by \"gmq\" or a sql.Scanner type like \"github.com/shopspring/decimal.Decimal\", default to float64")
+	flag.StringVar(&jsonTypes, "json-types", "", "Map the json columns to the go types, e.g. \"article.meta=github.com/me/myapp.ArticleMeta\"")
+	flag.StringVar(&columnTypes, "types", "", "Override the go types of the columns with the optional converters, e.g. \"user.email=net/mail.Address:github.com/me/myconv.AsAddress\"")
+	flag.StringVar(&nullable, "nullable", "", "Generate the nullable columns as sql.NullXxx by \"sql\", *T by \"pointer\" or gmq.Option[T] by \"option\", default to the zero values")
+	flag.StringVar(&naming, "naming", "", "Name the structs and fields by \"golint\" to keep the initialisms like UserID and HTTPURL, default to the capital case like UserId")
+	flag.StringVar(&initialisms, "initialisms", "", "Add the initialisms for the golint naming, e.g. \"SKU,VAT\"")
+	flag.StringVar(&names, "names", "", "Rename the structs of the tables and the fields of the columns, e.g. \"article=Post,article.user_id=AuthorID\"")
+	flag.BoolVar(&singular, "singular", false, "Singularize the table names for the structs and files, e.g. blog_posts => BlogPost")
+	flag.StringVar(&irregulars, "irregulars", "", "Add the irregular plurals for -singular, e.g. \"cacti=cactus,octopi=octopus\"")
+	flag.StringVar(&stripPrefixes, "strip-prefixes", "", "Strip the prefixes of the table names for the structs and files, e.g. \"tbl_,t_\"")
+	flag.StringVar(&stripSuffixes, "strip-suffixes", "", "Strip the suffixes of the table names for the structs and files, e.g. \"_tab\"")
+	flag.StringVar(&tags, "tags", "json", "The struct tags of the fields, e.g. \"json,db,yaml,xml\", the db tag is the column name")
+	flag.StringVar(&tagCase, "tag-case", "", "Use the lower camel case of the field names by \"camel\" for the tags except db, e.g. json:\"userId\", default to the column names")
+	flag.BoolVar(&omitempty, "omitempty", false, "Add the omitempty to the tags except db, e.g. json:\"user_id,omitempty\"")
+	flag.BoolVar(&validate, "validate", false, "Add the validate:\"max=50\" tags for the string columns by the lengths, e.g. varchar(50)")
+	flag.StringVar(&sensitive, "sensitive", "", "Omit the sensitive columns from the String() and json, and redact them in the debug logs, e.g. \"user.password,user.api_token\"")
 	flag.StringVar(&tmplName, "template", "", "Passing the template to generate code, or use the default one")
 	flag.IntVar(&pCount, "p", 4, "Parallell running for code generator")
 	flag.BoolVar(&gmq.Debug, "debug", false, "Debug on/off")
@@ -33,8 +56,31 @@ func main() {
 
 	runtime.GOMAXPROCS(pCount)
 
-	if targetDb == "" {
-		fmt.Println("Please provide the target database source.")
+	var config Config
+	if configFile == "" {
+		configFile = findConfigFile(".")
+	}
+	if configFile != "" {
+		var err error
+		if config, err = loadConfig(configFile); err != nil {
+			printUsages(err.Error())
+			return
+		}
+		setFlags := make(map[string]bool)
+		flag.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+		for name, value := range config.flagValues() {
+			if !setFlags[name] && value != "" {
+				if err := flag.Set(name, value); err != nil {
+					printUsages(fmt.Sprintf("Invalid %s in the config file %s, %s", name, configFile, err))
+					return
+				}
+			}
+		}
+		log.Printf("Using the config file %s", configFile)
+	}
+
+	if targetDb == "" && ddlFiles == "" {
+		fmt.Println("Please provide the target database source or the ddl files.")
 		fmt.Println("Usage:")
 		flag.PrintDefaults()
 		return
@@ -43,16 +89,69 @@ func main() {
 		printUsages("Please provide the go source code package name for generated models.")
 		return
 	}
-	if driver != "mysql" && driver != "postgres" {
-		printUsages("Current supported drivers include mysql, postgres.")
+	if driver != "mysql" && driver != "postgres" && driver != "sqlite" {
+		printUsages("Current supported drivers include mysql, postgres, sqlite.")
+		return
+	}
+	if nullable != "" && nullable != "sql" && nullable != "pointer" && nullable != "option" {
+		printUsages("Current supported nullable modes include sql, pointer, option.")
+		return
+	}
+	if decimal != "" && decimal != "gmq" && !strings.Contains(decimal, ".") {
+		printUsages("Please provide the decimal type with the import path, e.g. github.com/shopspring/decimal.Decimal.")
+		return
+	}
+	jsonTypesMap, err := parseColumnTypes(jsonTypes)
+	if err != nil {
+		printUsages(err.Error())
+		return
+	}
+	columnTypesMap, err := parseColumnTypes(columnTypes)
+	if err != nil {
+		printUsages(err.Error())
+		return
+	}
+	if naming != "" && naming != "golint" {
+		printUsages("Current supported naming strategies include golint.")
 		return
 	}
+	structNames, fieldNames, err := parseNames(names)
+	if err != nil {
+		printUsages(err.Error())
+		return
+	}
+	if tagCase != "" && tagCase != "camel" {
+		printUsages("Current supported tag cases include camel.")
+		return
+	}
+	irregularsMap, err := newIrregulars(irregulars)
+	if err != nil {
+		printUsages(err.Error())
+		return
+	}
+	sensitiveMap, err := parseSensitive(sensitive)
+	if err != nil {
+		printUsages(err.Error())
+		return
+	}
+	columnTags := make(map[string]string)
+	config.mergeColumns(columnTypesMap, jsonTypesMap, columnTags)
+	config.mergeSensitive(sensitiveMap)
+	config.mergeNames(structNames, fieldNames)
+	if schemaName == "" && driver == "sqlite" {
+		schemaName = "main"
+	}
 	if schemaName == "" {
 		printUsages("Please provide the schema name.")
 		return
 	}
 
-	dbSchema, err := drivers.LoadDatabaseSchema(driver, targetDb, schemaName, tableNames)
+	var dbSchema drivers.DbSchema
+	if ddlFiles != "" {
+		dbSchema, err = drivers.LoadDdlSchema(driver, ddlFiles, schemaName, tableNames)
+	} else {
+		dbSchema, err = drivers.LoadDatabaseSchema(driver, targetDb, schemaName, tableNames)
+	}
 	if err != nil {
 		log.Println("Cannot load table schemas from database.")
 		log.Fatal(err)
@@ -62,9 +161,31 @@ func main() {
 		packageName:    packageName,
 		touchTimestamp: touchTimestamp,
 		template:       tmplName,
+		commentEnums:   commentEnums,
+		nullable:       nullable,
+		strict:         strict,
+		decimal:        decimal,
+		jsonTypes:      jsonTypesMap,
+		columnTypes:    columnTypesMap,
+		structNames:    structNames,
+		fieldNames:     fieldNames,
+		columnTags:     columnTags,
+		naming:         naming,
+		initialisms:    newInitialisms(initialisms),
+		singular:       singular,
+		irregulars:     irregularsMap,
+		stripPrefixes:  splitList(stripPrefixes),
+		stripSuffixes:  splitList(stripSuffixes),
+		tags:           splitList(tags),
+		tagCase:        tagCase,
+		omitempty:      omitempty,
+		validate:       validate,
+		sensitive:      sensitiveMap,
 	}
 	codeConfig.MustCompileTemplate()
-	generateModels(schemaName, dbSchema, *codeConfig)
+	if err := generateModels(schemaName, dbSchema, *codeConfig); err != nil {
+		log.Fatal(err)
+	}
 	formatCodes(packageName)
 }
 
@@ -5,7 +5,7 @@ import (
 )
 
 var header string = `// Code generated by ModelQ
-// {{.TableName}}.go contains model for the database table [{{.DbName}}.{{.TableName}}]
+// {{.FileName}} contains model for the database table [{{.DbName}}.{{.TableName}}]
 
 package {{.PkgName}}
 
@@ -15,14 +15,60 @@ import (
 	{{if .ImportFmt}}"fmt"{{end}}
 	"strings"
 	"github.com/mijia/modelq/gmq"
-	"database/sql"
-	{{if .ImportTime}}"time"{{end}}
+	"database/sql"{{if .ImportDriver}}
+	"database/sql/driver"{{end}}
+	{{if .ImportTime}}"time"{{end}}{{range .Imports}}
+	"{{.}}"{{end}}
 )
 `
 
-var modelStruct string = `type {{.Name}} struct {
-	{{range .Fields}}{{.Name}} {{.Type}} {{.JsonMeta}}{{if .Comment}} // {{.Comment}}{{end}}
+var modelStruct string = `{{range $enum := .Enums}}type {{$enum.Name}} {{$enum.Type}}
+
+const (
+	{{range .Values}}{{.Name}} {{$enum.Name}} = {{.Literal}}
 	{{end}}
+)
+
+func (e {{$enum.Name}}) Valid() bool {
+	switch e {
+	case {{range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+{{if .IsString}}
+func (e *{{$enum.Name}}) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		*e = {{$enum.Name}}(v)
+	case string:
+		*e = {{$enum.Name}}(v)
+	case nil:
+		*e = ""
+	default:
+		return fmt.Errorf("Cannot scan %T into {{$enum.Name}}", value)
+	}
+	return nil
+}
+
+func (e {{$enum.Name}}) Value() (driver.Value, error) {
+	return string(e), nil
+}
+{{else}}
+func (e {{$enum.Name}}) String() string {
+	switch e {
+	{{range .Values}}case {{.Name}}:
+		return {{printf "%q" .Label}}
+	{{end}}}
+	return fmt.Sprintf("{{$enum.Name}}(%d)", e)
+}
+{{end}}
+{{end}}type {{.Name}} struct {
+	{{range .Fields}}{{.Name}} {{.FieldType}} {{.JsonMeta}}{{if .Comment}} // {{.Comment}}{{end}}
+	{{end}}{{if .HasRelations}}
+	{{range .BelongsTo}}preloaded{{.Name}} *{{.RefModel}}
+	{{end}}{{range .HasMany}}preloaded{{.Name}} *[]{{.RefModel}}
+	{{end}}{{end}}
 }
 `
 
@@ -81,13 +127,42 @@ func (obj {{.Name}}) Delete(dbtx gmq.DbTx) (int64, error) {
 		return result.RowsAffected()
 	}{{else}}return 0, gmq.ErrNoPrimaryKeyDefined{{end}}
 }
-`
+{{range .BelongsTo}}
+func (obj {{$.Name}}) {{.Name}}(dbtx gmq.DbTx) ({{.RefModel}}, error) {
+	if obj.preloaded{{.Name}} != nil {
+		return *obj.preloaded{{.Name}}, nil
+	}{{if .Field.NullType}}
+	if {{.Field.NullCheck "obj"}} {
+		return {{.RefModel}}{}, sql.ErrNoRows
+	}{{end}}
+	return {{.RefModel}}Objs.Select().Where({{.RefModel}}Objs.Filter{{.RefField.Name}}("=", {{.FieldValue}})).One(dbtx)
+}
+
+func (obj {{$.Name}}) Loaded{{.Name}}() ({{.RefModel}}, bool) {
+	if obj.preloaded{{.Name}} != nil {
+		return *obj.preloaded{{.Name}}, true
+	}
+	return {{.RefModel}}{}, false
+}
+{{end}}{{range .HasMany}}
+func (obj {{$.Name}}) {{.Name}}() _{{.RefModel}}Query {
+	return {{.RefModel}}Objs.Select().Where({{.RefModel}}Objs.Filter{{.RefField.Name}}("=", {{.FieldValue}}))
+}
+
+func (obj {{$.Name}}) Loaded{{.Name}}() ([]{{.RefModel}}, bool) {
+	if obj.preloaded{{.Name}} != nil {
+		return *obj.preloaded{{.Name}}, true
+	}
+	return nil, false
+}
+{{end}}`
 
 var queryApi string = `
 // Start of the inner Query Api
 
 type _{{.Name}}Query struct {
-	gmq.Query
+	gmq.Query{{if .HasRelations}}
+	preloads []string{{end}}
 }
 
 func (q _{{.Name}}Query) Where(f gmq.Filter) _{{.Name}}Query {
@@ -132,36 +207,90 @@ func (q _{{.Name}}Query) Page(number, size int) _{{.Name}}Query {
 	return q
 }
 
-func (q _{{.Name}}Query) Run(dbtx gmq.DbTx) (sql.Result, error) {
+{{if .HasRelations}}// Preload loads the relations by their accessor names with one more query for each after the rows are selected.
+func (q _{{.Name}}Query) Preload(relations ...string) _{{.Name}}Query {
+	preloads := make([]string, 0, len(q.preloads)+len(relations))
+	q.preloads = append(append(preloads, q.preloads...), relations...)
+	return q
+}
+
+{{end}}func (q _{{.Name}}Query) Run(dbtx gmq.DbTx) (sql.Result, error) {
 	return q.Query.Exec(dbtx)
 }
 
 type {{.Name}}RowVisitor func(obj {{.Name}}) bool
 
 func (q _{{.Name}}Query) Iterate(dbtx gmq.DbTx, functor {{.Name}}RowVisitor) error {
-	return q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := {{.Name}}Objs.to{{.Name}}(columns, rb)
+	{{if .HasRelations}}if len(q.preloads) > 0 {
+		// the relations can only be preloaded after all the rows are selected
+		result, err := q.List(dbtx)
+		if err != nil {
+			return err
+		}
+		for _, obj := range result {
+			if !functor(obj) {
+				break
+			}
+		}
+		return nil
+	}
+	{{end}}{{if .IsStrict}}var convErr error
+	err := q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj, err := {{.Name}}Objs.to{{.Name}}(dbtx, columns, rb)
+		if err != nil {
+			convErr = err
+			return false
+		}
 		return functor(obj)
 	})
+	if err == nil {
+		err = convErr
+	}
+	return err{{else}}return q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
+		obj := {{.Name}}Objs.to{{.Name}}(dbtx, columns, rb)
+		return functor(obj)
+	}){{end}}
 }
 
 func (q _{{.Name}}Query) One(dbtx gmq.DbTx) ({{.Name}}, error) {
-	var obj {{.Name}}
+	var obj {{.Name}}{{if .IsStrict}}
+	var convErr error{{end}}
 	err := q.Query.SelectOne(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj = {{.Name}}Objs.to{{.Name}}(columns, rb)
-		return true
-	})
-	return obj, err
+		{{if .IsStrict}}obj, convErr = {{.Name}}Objs.to{{.Name}}(dbtx, columns, rb)
+		return convErr == nil{{else}}obj = {{.Name}}Objs.to{{.Name}}(dbtx, columns, rb)
+		return true{{end}}
+	}){{if .IsStrict}}
+	if err == nil {
+		err = convErr
+	}{{end}}
+	{{if .HasRelations}}if err == nil && len(q.preloads) > 0 {
+		objs := []{{.Name}}{obj}
+		err = {{.Name}}Objs.preload(dbtx, objs, q.preloads...)
+		obj = objs[0]
+	}
+	{{end}}return obj, err
 }
 
 func (q _{{.Name}}Query) List(dbtx gmq.DbTx) ([]{{.Name}}, error) {
-	result := make([]{{.Name}}, 0, 10)
+	result := make([]{{.Name}}, 0, 10){{if .IsStrict}}
+	var convErr error{{end}}
 	err := q.Query.SelectList(dbtx, func(columns []gmq.Column, rb []sql.RawBytes) bool {
-		obj := {{.Name}}Objs.to{{.Name}}(columns, rb)
-		result = append(result, obj)
+		{{if .IsStrict}}obj, err := {{.Name}}Objs.to{{.Name}}(dbtx, columns, rb)
+		if err != nil {
+			convErr = err
+			return false
+		}
+		{{else}}obj := {{.Name}}Objs.to{{.Name}}(dbtx, columns, rb)
+		{{end}}result = append(result, obj)
 		return true
-	})
-	return result, err
+	}){{if .IsStrict}}
+	if err == nil {
+		err = convErr
+	}{{end}}
+	{{if .HasRelations}}if err == nil && len(q.preloads) > 0 {
+		err = {{.Name}}Objs.preload(dbtx, result, q.preloads...)
+	}
+	{{end}}return result, err
 }
 
 func (q _{{.Name}}Query) Count(dbtx gmq.DbTx) (int, error) {
@@ -222,15 +351,20 @@ func (o _{{.Name}}Objs) Delete() _{{.Name}}Query {
 	q.Query = gmq.Delete(o)
 	return q
 }
-
+{{range .Finders}}
+func (o _{{$.Name}}Objs) {{.FinderName}}(dbtx gmq.DbTx, {{.FormatParams}}) {{if .IsUnique}}({{$.Name}}, error){{else}}_{{$.Name}}Query{{end}} {
+	{{.FormatFilters $.Name}}
+	return o.Select().Where(filter){{if .IsUnique}}.One(dbtx){{end}}
+}
+{{end}}
 {{$ModelName := .Name }}
 ///// Managed Objects Filters definition
 {{range .Fields}}
 func (o _{{$ModelName}}Objs) Filter{{.Name}}(op string, p {{.Type}}, ps ...{{.Type}}) gmq.Filter {
 	params := make([]interface{}, 1+len(ps))
-	params[0] = p
+	params[0] = {{.SqlValue "p"}}
 	for i := range ps {
-		params[i+1] = ps[i]
+		params[i+1] = {{.SqlValue "ps[i]"}}
 	}
 	return o.newFilter("{{.ColumnName}}", op, params...)
 }
@@ -239,10 +373,10 @@ func (o _{{$ModelName}}Objs) Filter{{.Name}}(op string, p {{.Type}}, ps ...{{.Ty
 
 ///// Managed Objects Columns definition
 {{range .Fields}}
-func (o _{{$ModelName}}Objs) Column{{.Name}}(p ...{{.Type}}) gmq.Column {
+func (o _{{$ModelName}}Objs) Column{{.Name}}(p ...{{.FieldType}}) gmq.Column {
 	var value interface{}
 	if len(p) > 0 {
-		value = p[0]
+		value = {{.SqlValue "p[0]"}}
 	}
 	return gmq.Column{"{{.ColumnName}}", value}
 }
@@ -257,18 +391,34 @@ func (o _{{.Name}}Objs) newFilter(name, op string, params ...interface{}) gmq.Fi
 	return gmq.UnitFilter(name, op, params[0])
 }
 
-func (o _{{.Name}}Objs) to{{.Name}}(columns []gmq.Column, rb []sql.RawBytes) {{.Name}} {
+{{if .IsStrict}}func (o _{{.Name}}Objs) to{{.Name}}(dbtx gmq.DbTx, columns []gmq.Column, rb []sql.RawBytes) ({{.Name}}, error) {
 	obj := {{.Name}}{}
 	if len(columns) == len(rb) {
 		for i := range columns {
 			switch columns[i].Name {
 			{{range .Fields}}case "{{.ColumnName}}":
-				obj.{{.Name}} = gmq.{{.ConverterFuncName}}(rb[i])
+				{{if .ParseFrom "rb[i]"}}value, err := {{.ParseFrom "rb[i]"}}
+				if err != nil {
+					return obj, fmt.Errorf("Cannot convert the column {{$.TableName}}.{{.ColumnName}}, %s", err)
+				}
+				obj.{{.Name}} = {{.WrapValue "value" "rb[i]"}}
+				{{else}}obj.{{.Name}} = {{.ConvertFrom "rb[i]"}}
+			{{end}}{{end}} }
+		}
+	}
+	return obj, nil
+}{{else}}func (o _{{.Name}}Objs) to{{.Name}}(dbtx gmq.DbTx, columns []gmq.Column, rb []sql.RawBytes) {{.Name}} {
+	obj := {{.Name}}{}
+	if len(columns) == len(rb) {
+		for i := range columns {
+			switch columns[i].Name {
+			{{range .Fields}}case "{{.ColumnName}}":
+				obj.{{.Name}} = {{.ConvertFrom "rb[i]"}}
 			{{end}} }
 		}
 	}
 	return obj
-}
+}{{end}}
 
 func (o _{{.Name}}Objs) columns(fields ...string) []gmq.Column {
 	data := make([]gmq.Column, 0, len(fields))
@@ -292,6 +442,90 @@ func (o _{{.Name}}Objs) columnsWithData(obj {{.Name}}, fields ...string) []gmq.C
 	return data
 }
 
+{{if .HasRelations}}
+func (o _{{.Name}}Objs) preload(dbtx gmq.DbTx, objs []{{.Name}}, relations ...string) error {
+	for _, relation := range relations {
+		var err error
+		switch relation {
+		{{range .BelongsTo}}case "{{.Name}}":
+			err = o.preload{{.Name}}(dbtx, objs)
+		{{end}}{{range .HasMany}}case "{{.Name}}":
+			err = o.preload{{.Name}}(dbtx, objs)
+		{{end}}default:
+			err = gmq.ErrUnknownRelation
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+{{range .BelongsTo}}
+func (o _{{$.Name}}Objs) preload{{.Name}}(dbtx gmq.DbTx, objs []{{$.Name}}) error {
+	keys := make(map[{{.KeyType}}]bool)
+	params := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {{"{"}}{{if .Field.NullType}}
+		if {{.Field.NullCheck "obj"}} {
+			continue
+		}{{end}}
+		if key := {{.FieldValue}}; !keys[key] {
+			keys[key] = true
+			params = append(params, key)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	refs, err := {{.RefModel}}Objs.Select().Where(gmq.InFilter("{{.RefField.ColumnName}}", params)).List(dbtx)
+	if err != nil {
+		return err
+	}
+	refMap := make(map[{{.KeyType}}]*{{.RefModel}}, len(refs))
+	for i := range refs {
+		refMap[refs[i].{{.RefField.Name}}] = &refs[i]
+	}
+	for i, obj := range objs {{"{"}}{{if .Field.NullType}}
+		if {{.Field.NullCheck "obj"}} {
+			continue
+		}{{end}}
+		objs[i].preloaded{{.Name}} = refMap[{{.FieldValue}}]
+	}
+	return nil
+}
+{{end}}{{range .HasMany}}
+func (o _{{$.Name}}Objs) preload{{.Name}}(dbtx gmq.DbTx, objs []{{$.Name}}) error {
+	keys := make(map[{{.KeyType}}]bool)
+	params := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {{"{"}}{{if .Field.NullType}}
+		if {{.Field.NullCheck "obj"}} {
+			continue
+		}{{end}}
+		if key := {{.FieldValue}}; !keys[key] {
+			keys[key] = true
+			params = append(params, key)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	refs, err := {{.RefModel}}Objs.Select().Where(gmq.InFilter("{{.RefField.ColumnName}}", params)).List(dbtx)
+	if err != nil {
+		return err
+	}
+	groups := make(map[{{.KeyType}}][]{{.RefModel}})
+	for _, ref := range refs {{"{"}}{{if .RefField.NullType}}
+		if {{.RefField.NullCheck "ref"}} {
+			continue
+		}{{end}}
+		groups[{{.RefField.ValueOf "ref"}}] = append(groups[{{.RefField.ValueOf "ref"}}], ref)
+	}
+	for i, obj := range objs {
+		group := append([]{{.RefModel}}{}, groups[{{.FieldValue}}]...)
+		objs[i].preloaded{{.Name}} = &group
+	}
+	return nil
+}
+{{end}}{{end}}
 var {{.Name}}Objs _{{.Name}}Objs
 
 func init() {
@@ -1,9 +1,21 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/mijia/modelq/drivers"
 	"github.com/mijia/modelq/gmq"
+	"io/ioutil"
 	"log"
+	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestOptions(t *testing.T) {
@@ -41,21 +53,524 @@ func TestCapitalCase(t *testing.T) {
 	}
 }
 
+func TestRelationNames(t *testing.T) {
+	cases := [][]string{
+		[]string{"user_id", "user", "user", "users"},
+		[]string{"editor_id", "user", "editor", "editors"},
+		[]string{"categoryId", "category", "category", "categories"},
+		[]string{"box_id", "box", "box", "boxes"},
+	}
+	for _, cs := range cases {
+		if target := relationBaseName(cs[0], cs[1]); target != cs[2] {
+			t.Errorf("src %s, expected %s, got %s", cs[0], cs[2], target)
+		}
+		if target := pluralize(cs[2]); target != cs[3] {
+			t.Errorf("src %s, expected %s, got %s", cs[2], cs[3], target)
+		}
+	}
+}
+
+func TestEnumRelations(t *testing.T) {
+	cases := []struct {
+		dialect  string
+		ddl      string
+		expected string
+	}{
+		{"mysql", `CREATE TABLE kind (code ENUM('a', 'b') PRIMARY KEY);
+			CREATE TABLE item (id INT PRIMARY KEY, kind ENUM('a', 'b'), FOREIGN KEY (kind) REFERENCES kind(code));`, ""},
+		{"postgres", `CREATE TYPE kcode AS ENUM ('a', 'b');
+			CREATE TABLE kind (code kcode PRIMARY KEY);
+			CREATE TABLE item (id INT PRIMARY KEY, kind kcode REFERENCES kind (code));`, "Kcode"},
+	}
+	for _, cs := range cases {
+		file, err := ioutil.TempFile("", "modelq_relation")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(file.Name())
+		file.WriteString(cs.ddl)
+		file.Close()
+
+		dbSchema, err := drivers.LoadDdlSchema(cs.dialect, file.Name(), "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		cc := CodeConfig{nullable: "pointer", tags: []string{"json"}}
+		enums, err := cc.modelEnums(dbSchema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		modelFields := make(map[string][]ModelField)
+		for tName, schema := range dbSchema {
+			if modelFields[tName], err = cc.buildModelFields(tName, schema, enums); err != nil {
+				t.Fatal(err)
+			}
+		}
+		item := ModelMeta{Name: "Item", TableName: "item", Fields: modelFields["item"], ForeignKeys: modelFields["item"][1:], config: cc}
+		kind := ModelMeta{Name: "Kind", TableName: "kind", Fields: modelFields["kind"], config: cc}
+		belongsTo, _ := buildRelations(item, dbSchema, modelFields)
+		_, hasMany := buildRelations(kind, dbSchema, modelFields)
+		if cs.expected == "" {
+			if len(belongsTo) != 0 || len(hasMany) != 0 {
+				t.Errorf("[%s] Expected no relations between the different enum types, got %+v, %+v", cs.dialect, belongsTo, hasMany)
+			}
+			continue
+		}
+		if len(belongsTo) != 1 || belongsTo[0].KeyType() != cs.expected || belongsTo[0].FieldValue() != "*obj.Kind" {
+			t.Errorf("[%s] Expected the relation by the enum type %s, got %+v", cs.dialect, cs.expected, belongsTo)
+		}
+		if len(hasMany) != 1 || hasMany[0].Field.Type != cs.expected || hasMany[0].KeyType() != cs.expected {
+			t.Errorf("[%s] Expected the reverse relation by the enum type %s, got %+v", cs.dialect, cs.expected, hasMany)
+		}
+		kind.HasMany = hasMany
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		if err := kind.GenManagedObjApi(w, nil); err != nil {
+			t.Fatal(err)
+		}
+		w.Flush()
+		if code := buf.String(); !strings.Contains(code, "keys := make(map[Kcode]bool)") ||
+			!strings.Contains(code, "groups := make(map[Kcode][]Item)") {
+			t.Errorf("[%s] Expected the preload maps keyed by the enum type, got %s", cs.dialect, code)
+		}
+	}
+}
+
 func TestGmqFilters(t *testing.T) {
 	left := gmq.UnitFilter("id", "=", 1)
-	log.Println(left.SqlString("User"), left.Params())
+	log.Println(left.SqlString("User", "mysql"), left.Params())
 
 	right := gmq.UnitFilter("name", "LIKE", "hello%")
-	log.Println(right.SqlString("User"), right.Params())
+	log.Println(right.SqlString("User", "mysql"), right.Params())
 
 	and := left.And(right)
-	log.Println(and.SqlString("User"), and.Params())
+	log.Println(and.SqlString("User", "mysql"), and.Params())
 
 	in := gmq.InFilter("id", []interface{}{10, 20, 30})
-	log.Println(in.SqlString("User"), in.Params())
+	log.Println(in.SqlString("User", "mysql"), in.Params())
 
 	or := and.Or(in)
-	log.Println(or.SqlString("User"), or.Params())
+	log.Println(or.SqlString("User", "mysql"), or.Params())
+}
+
+func TestGmqFilterQuotes(t *testing.T) {
+	cases := map[string]string{
+		"mysql":    "`User`.`id` = ?",
+		"postgres": "\"User\".\"id\" = ?",
+		"sqlite3":  "\"User\".\"id\" = ?",
+	}
+	for driverName, expected := range cases {
+		if target := gmq.UnitFilter("id", "=", 1).SqlString("User", driverName); target != expected {
+			t.Errorf("driver %s, expected %s, got %s", driverName, expected, target)
+		}
+	}
+}
+
+func TestDdlSchema(t *testing.T) {
+	cases := [][]string{
+		[]string{"mysql", "examples/blog.mysql.sql", "blog"},
+		[]string{"postgres", "examples/blog.pq.sql", "public"},
+		[]string{"sqlite", "examples/blog.sqlite.sql", "main"},
+	}
+	for _, cs := range cases {
+		dbSchema, err := drivers.LoadDdlSchema(cs[0], cs[1], cs[2], "user,article")
+		if err != nil {
+			t.Fatalf("Fail to load the ddl schema from %s, %s", cs[1], err)
+		}
+		if len(dbSchema) != 2 {
+			t.Errorf("[%s] expected 2 tables, got %d", cs[0], len(dbSchema))
+		}
+		user := dbSchema["user"]
+		if len(user) < 5 || user[0].ColumnName != "id" || user[4].ColumnName != "age" {
+			t.Fatalf("[%s] the columns of user are not loaded in order, %v", cs[0], user)
+		}
+		if user[0].ColumnKey != "PRI" || user[0].Extra != "AUTO_INCREMENT" || user[0].DataType != "int64" {
+			t.Errorf("[%s] expected an auto increment primary key, got %+v", cs[0], user[0])
+		}
+		if user[1].ColumnKey != "UNI" || user[1].IsNullable != "NO" || user[1].DataType != "string" {
+			t.Errorf("[%s] expected a not null unique key, got %+v", cs[0], user[1])
+		}
+		if user[4].ColumnKey != "MUL" || user[4].IsNullable != "YES" {
+			t.Errorf("[%s] expected a nullable indexed column, got %+v", cs[0], user[4])
+		}
+		if fKey := dbSchema["article"][1].ForeignKey; fKey == nil || fKey.RefTable != "user" || fKey.RefColumn != "id" || fKey.OnDelete != "CASCADE" {
+			t.Errorf("[%s] expected the foreign key to user.id, got %+v", cs[0], fKey)
+		}
+		if len(dbSchema["user"].ForeignKeys()) != 0 {
+			t.Errorf("[%s] expected no foreign keys for user", cs[0])
+		}
+		indexes := dbSchema["article"].Indexes()
+		if len(indexes) < 2 || !indexes[0].IsPrimary || indexes[0].Columns[0] != "id" {
+			t.Fatalf("[%s] expected the primary key as the first index, got %+v", cs[0], indexes)
+		}
+		composite := indexes[len(indexes)-1]
+		if composite.Name != "UNIQUE_INDEX_article_user_title" || !composite.IsUnique ||
+			len(composite.Columns) != 2 || composite.Columns[0] != "user_id" || composite.Columns[1] != "title" {
+			t.Errorf("[%s] expected the composite unique index, got %+v", cs[0], composite)
+		}
+		if title := dbSchema["article"][2]; title.ColumnKey != "" || len(title.Indexes) != 1 {
+			t.Errorf("[%s] expected the title only in the composite index, got %+v", cs[0], title)
+		}
+		if donation := dbSchema["article"][5]; donation.DefaultValue != "0.5" || donation.ColumnType != "decimal(12,2)" {
+			t.Errorf("[%s] expected the default value and column type, got %+v", cs[0], donation)
+		}
+	}
+
+	dbSchema, _ := drivers.LoadDdlSchema("mysql", "examples/blog.mysql.sql", "blog", "article")
+	if state := dbSchema["article"][3]; state.Comment != "0: published, 1: draft, 2: hidden" {
+		t.Errorf("expected the column comment, got %+v", state)
+	}
+	if updateTime := dbSchema["article"][7]; updateTime.Extra != "on update CURRENT_TIMESTAMP" {
+		t.Errorf("expected the on update extra, got %+v", updateTime)
+	}
+}
+
+func TestDdlAlterTable(t *testing.T) {
+	cases := [][]string{
+		[]string{"postgres", `CREATE TYPE mood AS ENUM ('happy', 'sad');
+			CREATE TABLE "u" ("id" INT PRIMARY KEY, "age" INT NOT NULL DEFAULT 1, "m" TEXT);
+			CREATE TABLE "a" ("id" INT PRIMARY KEY, "uid" INT REFERENCES "u" ("id"));
+			ALTER TABLE "u" ALTER COLUMN "age" TYPE BIGINT USING "age"::bigint, ALTER "m" SET DATA TYPE mood;
+			ALTER TABLE "a" ALTER COLUMN "uid" TYPE BIGINT;
+			ALTER TABLE "u" RENAME TO "users";
+			ALTER TABLE "users" RENAME COLUMN "id" TO "user_id";`},
+		[]string{"mysql", "CREATE TABLE `u` (`id` INT PRIMARY KEY, `age` INT NOT NULL DEFAULT 1, `m` TEXT);" +
+			"CREATE TABLE `a` (`id` INT PRIMARY KEY, `uid` INT, FOREIGN KEY (`uid`) REFERENCES `u` (`id`));" +
+			"ALTER TABLE `u` MODIFY `age` BIGINT NOT NULL DEFAULT 1, MODIFY `m` ENUM('happy', 'sad');" +
+			"ALTER TABLE `a` MODIFY `uid` BIGINT;" +
+			"RENAME TABLE `u` TO `users`;" +
+			"ALTER TABLE `users` CHANGE `id` `user_id` INT;"},
+	}
+	for _, cs := range cases {
+		file, err := ioutil.TempFile("", "modelq_alter")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(file.Name())
+		file.WriteString(cs[1])
+		file.Close()
+
+		dbSchema, err := drivers.LoadDdlSchema(cs[0], file.Name(), "", "")
+		if err != nil {
+			t.Fatalf("[%s] Fail to load the ddl schema, %s", cs[0], err)
+		}
+		users := dbSchema["users"]
+		if len(users) != 3 || users[0].ColumnName != "user_id" || users[0].ColumnKey != "PRI" {
+			t.Fatalf("[%s] Expected the renamed table and column, got %+v", cs[0], users)
+		}
+		if age := users[1]; age.DataType != "int64" || age.IsNullable != "NO" || age.DefaultValue != "1" {
+			t.Errorf("[%s] Expected the new type of the column, got %+v", cs[0], age)
+		}
+		if m := users[2]; len(m.EnumValues) != 2 || m.EnumValues[1] != "sad" {
+			t.Errorf("[%s] Expected the enum type of the column, got %+v", cs[0], m)
+		}
+		uid := dbSchema["a"][1]
+		if fKey := uid.ForeignKey; uid.DataType != "int64" || fKey == nil || fKey.RefTable != "users" || fKey.RefColumn != "user_id" {
+			t.Errorf("[%s] Expected the foreign key to the renamed table and column, got %+v, %+v", cs[0], uid, fKey)
+		}
+	}
+}
+
+func TestPartialUniqueIndexes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "modelq_partial")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ddl := `CREATE TABLE account (id INTEGER PRIMARY KEY, email TEXT NOT NULL, name TEXT NOT NULL, deleted_at TIMESTAMP);
+		CREATE UNIQUE INDEX account_email_key ON account (email) WHERE deleted_at IS NULL;
+		CREATE UNIQUE INDEX account_name_key ON account (name);`
+	ioutil.WriteFile(dir+"/account.sql", []byte(ddl), 0644)
+
+	ddlSchema, err := drivers.LoadDdlSchema("postgres", dir+"/account.sql", "public", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite3", dir+"/account.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(ddl); err != nil {
+		t.Fatal(err)
+	}
+	sqliteSchema, err := drivers.SqliteDriver{}.LoadDatabaseSchema(dir+"/account.db", "main", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for driverName, dbSchema := range map[string]drivers.DbSchema{"ddl": ddlSchema, "sqlite": sqliteSchema} {
+		account := dbSchema["account"]
+		if len(account) != 4 || account[1].ColumnKey != "MUL" || account[2].ColumnKey != "UNI" {
+			t.Errorf("[%s] expected the partial unique index as a normal index, got %+v", driverName, account)
+		}
+	}
+}
+
+func TestFinderNames(t *testing.T) {
+	index := ModelIndex{
+		Fields: []ModelField{
+			ModelField{Name: "UserId", Type: "int64"},
+			ModelField{Name: "Type", Type: "string"},
+			ModelField{Name: "URLPath", Type: "string"},
+		},
+		IsUnique: true,
+	}
+	if name := index.FinderName(); name != "GetByUserIdTypeURLPath" {
+		t.Errorf("Unexpected finder name, %s", name)
+	}
+	if params := index.FormatParams(); params != "userId int64, typeValue string, urlPath string" {
+		t.Errorf("Unexpected finder params, %s", params)
+	}
+	index.IsUnique = false
+	if name := index.FinderName(); name != "FindByUserIdTypeURLPath" {
+		t.Errorf("Unexpected finder name, %s", name)
+	}
+}
+
+func TestGmqArrayConverters(t *testing.T) {
+	strs := gmq.AsStringArray(sql.RawBytes(`{hello,"a b","say \"hi\"",NULL,"NULL"}`))
+	if len(strs) != 5 || strs[0] != "hello" || strs[1] != "a b" || strs[2] != `say "hi"` || strs[3] != "" || strs[4] != "NULL" {
+		t.Errorf("Fail to parse the string array, %q", strs)
+	}
+	if ints := gmq.AsInt64Array(sql.RawBytes("{{1,2},{3,4}}")); len(ints) != 4 || ints[3] != 4 {
+		t.Errorf("Fail to parse the nested int array, %v", ints)
+	}
+	if ints := gmq.AsIntArray(sql.RawBytes("{}")); ints == nil || len(ints) != 0 {
+		t.Errorf("Expected an empty array, got %v", ints)
+	}
+	if ints := gmq.AsIntArray(nil); ints != nil {
+		t.Errorf("Expected a nil array for NULL, got %v", ints)
+	}
+	if bools := gmq.AsBoolArray(sql.RawBytes("{t,f,true}")); len(bools) != 3 || !bools[0] || bools[1] || !bools[2] {
+		t.Errorf("Fail to parse the bool array, %v", bools)
+	}
+	if tm := gmq.AsTime(sql.RawBytes("2015-06-01T10:20:30.123+08:00")); tm.Nanosecond() != 123000000 || tm.Hour() != 10 {
+		t.Errorf("Fail to parse the RFC3339 time, %s", tm)
+	}
+}
+
+func TestGmqMysqlConverters(t *testing.T) {
+	if d := gmq.AsDuration(sql.RawBytes("-838:59:59.500000")); d != -(838*time.Hour + 59*time.Minute + 59*time.Second + 500*time.Millisecond) {
+		t.Errorf("Fail to parse the TIME value, %s", d)
+	}
+	if d := gmq.AsDuration(sql.RawBytes("12:30:00")); d != 12*time.Hour+30*time.Minute {
+		t.Errorf("Fail to parse the TIME value, %s", d)
+	}
+	if n := gmq.AsUint64(sql.RawBytes("18446744073709551615")); n != 18446744073709551615 {
+		t.Errorf("Fail to parse the unsigned bigint, %d", n)
+	}
+	if n := gmq.AsBit(sql.RawBytes{0x01, 0x02}); n != 258 {
+		t.Errorf("Fail to parse the bit value, %d", n)
+	}
+	if set := gmq.AsSet(sql.RawBytes("a,b")); len(set) != 2 || set[1] != "b" {
+		t.Errorf("Fail to parse the set value, %v", set)
+	}
+}
+
+func TestMysqlDataTypes(t *testing.T) {
+	ddl := "CREATE TABLE `event` (" +
+		"`id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY," +
+		"`count` INT(10) UNSIGNED," +
+		"`level` MEDIUMINT," +
+		"`body` LONGTEXT," +
+		"`raw` LONGBLOB," +
+		"`meta` JSON," +
+		"`year` YEAR," +
+		"`duration` TIME," +
+		"`state` ENUM('on', 'off')," +
+		"`flags` SET('a', 'b')," +
+		"`bits` BIT(8))"
+	file, err := ioutil.TempFile("", "modelq_event")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString(ddl)
+	file.Close()
+
+	dbSchema, err := drivers.LoadDdlSchema("mysql", file.Name(), "test", "")
+	if err != nil {
+		t.Fatalf("Fail to load the ddl schema, %s", err)
+	}
+	expected := []string{"uint64", "uint32", "int", "string", "[]byte", "json.RawMessage", "int", "time.Duration", "string", "[]string", "uint64"}
+	converters := []string{"AsUint64", "AsUint32", "AsInt", "AsString", "AsByteArray", "AsJson", "AsInt", "AsDuration", "AsString", "AsSet", "AsBit"}
+	for i, col := range dbSchema["event"] {
+		if col.DataType != expected[i] {
+			t.Errorf("Expected %s for the column %s, got %s", expected[i], col.ColumnName, col.DataType)
+		}
+		if converter := newModelField(col).ConverterFuncName(); converter != converters[i] {
+			t.Errorf("Expected %s for the column %s, got %s", converters[i], col.ColumnName, converter)
+		}
+	}
+}
+
+func TestEnumColumns(t *testing.T) {
+	cases := [][]string{
+		[]string{"mysql", "CREATE TABLE `post` (`id` INT PRIMARY KEY, `status` ENUM('draft', 'it''s', 'in-progress'));"},
+		[]string{"postgres", `CREATE TYPE "post_status" AS ENUM ('draft', 'in-progress');
+			CREATE TABLE "post" ("id" INT PRIMARY KEY, "status" post_status);
+			ALTER TYPE "post_status" ADD VALUE 'it''s' BEFORE 'in-progress';`},
+	}
+	for _, cs := range cases {
+		file, err := ioutil.TempFile("", "modelq_post")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(file.Name())
+		file.WriteString(cs[1])
+		file.Close()
+
+		dbSchema, err := drivers.LoadDdlSchema(cs[0], file.Name(), "", "")
+		if err != nil {
+			t.Fatalf("[%s] Fail to load the ddl schema, %s", cs[0], err)
+		}
+		values := dbSchema["post"][1].EnumValues
+		if len(values) != 3 || values[0] != "draft" || values[1] != "it's" || values[2] != "in-progress" {
+			t.Errorf("[%s] Unexpected enum values, %q", cs[0], values)
+		}
+	}
+
+	enum := CodeConfig{}.newModelEnum("PostStatus", []string{"draft", "in-progress", "in_progress", ""})
+	names := []string{"PostStatusDraft", "PostStatusInProgress", "PostStatusInProgress2", "PostStatusEmpty"}
+	for i, value := range enum.Values {
+		if value.Name != names[i] {
+			t.Errorf("Expected the enum constant %s, got %s", names[i], value.Name)
+		}
+	}
+}
+
+func TestSharedEnumTypes(t *testing.T) {
+	file, err := ioutil.TempFile("", "modelq_mood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString(`CREATE TYPE mood AS ENUM ('happy', 'sad');
+		CREATE TABLE account (id INT PRIMARY KEY, m mood, m2 mood);
+		CREATE TABLE profile (id INT PRIMARY KEY, mood mood NOT NULL);`)
+	file.Close()
+
+	dbSchema, err := drivers.LoadDdlSchema("postgres", file.Name(), "public", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col := dbSchema["account"][1]; col.EnumType != "mood" || len(col.EnumValues) != 2 {
+		t.Errorf("Expected the enum type of the column, got %+v", col)
+	}
+	enums, err := CodeConfig{}.modelEnums(dbSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"account.m", "account.m2", "profile.mood"} {
+		if enum := enums[key]; enum.Name != "Mood" || enum.table != "account" || enum.Values[0].Name != "MoodHappy" {
+			t.Errorf("Expected the shared enum type Mood for %s, got %+v", key, enum)
+		}
+	}
+}
+
+func TestEnumNameCollisions(t *testing.T) {
+	cases := []struct {
+		dialect  string
+		ddl      string
+		config   CodeConfig
+		key      string
+		expected []string
+	}{
+		{"mysql", "CREATE TABLE article (id INT PRIMARY KEY, status ENUM('draft', 'published')); CREATE TABLE article_status (id INT PRIMARY KEY);",
+			CodeConfig{}, "article.status", []string{"ArticleStatusEnum", "ArticleStatusEnumDraft"}},
+		{"mysql", "CREATE TABLE users (id INT PRIMARY KEY, state ENUM('active', 'banned')); CREATE TABLE user_state (id INT PRIMARY KEY);",
+			CodeConfig{singular: true}, "users.state", []string{"UserStateEnum", "UserStateEnumActive"}},
+		{"postgres", "CREATE TYPE mood AS ENUM ('happy', 'sad'); CREATE TABLE account (id INT PRIMARY KEY, m mood); CREATE TABLE mood (id INT PRIMARY KEY);",
+			CodeConfig{}, "account.m", []string{"MoodEnum", "MoodEnumHappy"}},
+		{"postgres", "CREATE TYPE status AS ENUM ('draft'); CREATE TABLE status_draft (id INT PRIMARY KEY, s status);",
+			CodeConfig{}, "status_draft.s", []string{"StatusEnum", "StatusEnumDraft"}},
+	}
+	for _, cs := range cases {
+		file, err := ioutil.TempFile("", "modelq_collision")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(file.Name())
+		file.WriteString(cs.ddl)
+		file.Close()
+
+		dbSchema, err := drivers.LoadDdlSchema(cs.dialect, file.Name(), "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cs.config.checkModelNames(dbSchema); err != nil {
+			t.Fatal(err)
+		}
+		enums, err := cs.config.modelEnums(dbSchema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if enum := enums[cs.key]; enum.Name != cs.expected[0] || enum.Values[0].Name != cs.expected[1] {
+			t.Errorf("Expected the enum %s renamed as %v, got %+v", cs.key, cs.expected, enum)
+		}
+	}
+
+	dbSchema := drivers.DbSchema{
+		"article":             drivers.TableSchema{{TableName: "article", ColumnName: "status", EnumValues: []string{"draft"}}},
+		"article_status":      nil,
+		"article_status_enum": nil,
+	}
+	if _, err := (CodeConfig{}).modelEnums(dbSchema); err == nil {
+		t.Errorf("Expected the error for the enum names used by the models")
+	}
+}
+
+func TestCommentEnums(t *testing.T) {
+	values, labels, ok := parseCommentEnum("0: published, 1: draft; 2: hidden")
+	if !ok || len(values) != 3 || values[2] != "2" || labels[2] != "hidden" {
+		t.Errorf("Fail to parse the comment enum, %v, %v", values, labels)
+	}
+	for _, comment := range []string{"", "the state of article", "0: published, draft", "a: published", "0: a, 0: b"} {
+		if _, _, ok := parseCommentEnum(comment); ok {
+			t.Errorf("Expected no enum from the comment %q", comment)
+		}
+	}
+
+	enum := CodeConfig{}.newModelIntEnum("ArticleState", "int", values, labels)
+	if enum.IsString() || enum.Values[0].Name != "ArticleStatePublished" || enum.Values[0].Literal != "0" || enum.Values[0].Label != "published" {
+		t.Errorf("Unexpected int enum, %+v", enum)
+	}
+	if value := (CodeConfig{}).newModelEnum("PostStatus", []string{"draft"}).Values[0]; value.Literal != `"draft"` {
+		t.Errorf("Unexpected string enum literal, %s", value.Literal)
+	}
+}
+
+func TestPostgresDataTypes(t *testing.T) {
+	ddl := `CREATE TABLE "event" (
+		"id" UUID PRIMARY KEY,
+		"payload" JSONB,
+		"raw" BYTEA,
+		"tags" TEXT[],
+		"scores" INTEGER[],
+		"happened_at" TIMESTAMP WITH TIME ZONE,
+		"day" DATE,
+		"ratio" REAL,
+		"amount" DOUBLE PRECISION,
+		"count" BIGINT
+	);`
+	file, err := ioutil.TempFile("", "modelq_event")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString(ddl)
+	file.Close()
+
+	dbSchema, err := drivers.LoadDdlSchema("postgres", file.Name(), "public", "")
+	if err != nil {
+		t.Fatalf("Fail to load the ddl schema, %s", err)
+	}
+	expected := []string{"string", "json.RawMessage", "[]byte", "[]string", "[]int", "time.Time", "time.Time", "float32", "float64", "int64"}
+	for i, col := range dbSchema["event"] {
+		if col.DataType != expected[i] {
+			t.Errorf("Expected %s for the column %s, got %s", expected[i], col.ColumnName, col.DataType)
+		}
+	}
 }
 
 func init() {
@@ -63,3 +578,806 @@ func init() {
 }
 
 var _ = log.Println
+
+func TestNullableFields(t *testing.T) {
+	cases := [][]string{
+		[]string{"sql", "int64", "sql.NullInt64", "sql.NullInt64{Int64: gmq.AsInt64(rb), Valid: rb != nil}", "obj.Age.Int64"},
+		[]string{"sql", "uint32", "sql.Null[uint32]", "sql.Null[uint32]{V: gmq.AsUint32(rb), Valid: rb != nil}", "obj.Age.V"},
+		[]string{"pointer", "int", "*int", "gmq.Ptr(gmq.AsInt(rb), rb != nil)", "*obj.Age"},
+		[]string{"option", "int", "gmq.Option[int]", "gmq.NewOption(gmq.AsInt(rb), rb != nil)", "gmq.OptionValue(obj.Age.Get())"},
+		[]string{"", "int", "int", "gmq.AsInt(rb)", "obj.Age"},
+	}
+	for _, cs := range cases {
+		field := ModelField{Name: "Age", Type: cs[1], IsNullable: true}
+		field.setNullable(cs[0])
+		if field.FieldType() != cs[2] || field.ConvertFrom("rb") != cs[3] || field.ValueOf("obj") != cs[4] {
+			t.Errorf("[%s] Unexpected nullable field of %s, %s, %s, %s", cs[0], cs[1], field.FieldType(), field.ConvertFrom("rb"), field.ValueOf("obj"))
+		}
+	}
+
+	field := ModelField{Name: "Tags", Type: "[]string", IsNullable: true}
+	if field.setNullable("pointer"); field.FieldType() != "[]string" {
+		t.Errorf("The slices should not be wrapped, got %s", field.FieldType())
+	}
+
+	if p := gmq.Ptr(gmq.AsInt(nil), false); p != nil {
+		t.Errorf("Expected a nil pointer for NULL, got %v", *p)
+	}
+	if p := gmq.Ptr(gmq.AsInt(sql.RawBytes("0")), true); p == nil || *p != 0 {
+		t.Errorf("Expected a pointer to zero, got %v", p)
+	}
+	if o := gmq.AsOptionInt(nil); o.IsDefined() {
+		t.Errorf("Expected an undefined option for NULL")
+	}
+	if o := gmq.AsOptionString(sql.RawBytes("")); !o.IsDefined() {
+		t.Errorf("Expected a defined option for the empty string")
+	}
+}
+
+func TestGmqOption(t *testing.T) {
+	type user struct {
+		Name gmq.Option[string] `json:"name"`
+		Age  gmq.Option[int]    `json:"age"`
+	}
+	data, err := json.Marshal(user{Name: gmq.Some("mijia")})
+	if err != nil || string(data) != `{"name":"mijia","age":null}` {
+		t.Errorf("Unexpected json of the options, %s, %v", data, err)
+	}
+	var u user
+	if err := json.Unmarshal([]byte(`{"name":null,"age":0}`), &u); err != nil || u.Name.IsDefined() || !u.Age.IsDefined() {
+		t.Errorf("Unexpected options from json, %+v, %v", u, err)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(user{Age: gmq.Some(0)}); err != nil {
+		t.Fatal(err)
+	}
+	u = user{}
+	if err := gob.NewDecoder(&buf).Decode(&u); err != nil || u.Name.IsDefined() {
+		t.Errorf("Unexpected options from gob, %+v, %v", u, err)
+	}
+	if age, err := u.Age.Get(); err != nil || age != 0 {
+		t.Errorf("Expected the defined zero age from gob, %d, %v", age, err)
+	}
+
+	var o gmq.Option[int64]
+	if err := o.Scan([]byte("42")); err != nil || gmq.OptionValue(o.Get()) != 42 {
+		t.Errorf("Fail to scan the option, %+v, %v", o, err)
+	}
+	if err := o.Scan(nil); err != nil || o.IsDefined() {
+		t.Errorf("Expected the none for NULL, %+v, %v", o, err)
+	}
+	if v, err := o.Value(); err != nil || v != nil {
+		t.Errorf("Expected NULL for the none, %v, %v", v, err)
+	}
+	if v, err := gmq.SomeInt(3).Value(); err != nil || v != int64(3) {
+		t.Errorf("Unexpected driver value of the option, %v, %v", v, err)
+	}
+}
+
+// recordDriver keeps the args of the last Exec and accepts any value like the mysql driver does for uint64
+type recordDriver struct{ args []driver.Value }
+type recordConn struct{ d *recordDriver }
+type recordStmt struct{ d *recordDriver }
+type recordModel struct{}
+
+func (d *recordDriver) Open(name string) (driver.Conn, error)       { return recordConn{d}, nil }
+func (c recordConn) Prepare(query string) (driver.Stmt, error)      { return recordStmt{c.d}, nil }
+func (c recordConn) Close() error                                   { return nil }
+func (c recordConn) Begin() (driver.Tx, error)                      { return nil, driver.ErrSkip }
+func (c recordConn) CheckNamedValue(nv *driver.NamedValue) error    { return nil }
+func (s recordStmt) Close() error                                   { return nil }
+func (s recordStmt) NumInput() int                                  { return -1 }
+func (s recordStmt) Query(args []driver.Value) (driver.Rows, error) { return nil, driver.ErrSkip }
+func (s recordStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.args = args
+	return driver.RowsAffected(1), nil
+}
+func (m recordModel) Names() (string, string, string) { return "", "user", "" }
+
+var recorder = &recordDriver{}
+
+func init() {
+	sql.Register("modelq_record", recorder)
+}
+
+func TestGmqUnsignedParams(t *testing.T) {
+	db, err := gmq.Open("modelq_record", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	id := uint64(1) << 63
+	columns := []gmq.Column{
+		{Name: "id", Value: sql.Null[uint64]{V: id, Valid: true}},
+		{Name: "parent_id", Value: gmq.Some(id + 1)},
+		{Name: "age", Value: gmq.Some(uint32(30))},
+		{Name: "owner_id", Value: gmq.None[uint64]()},
+	}
+	if _, err := gmq.Insert(recordModel{}, columns).Exec(db); err != nil {
+		t.Fatalf("Fail to bind the nullable unsigned params, %v", err)
+	}
+	expected := []driver.Value{id, id + 1, uint32(30), nil}
+	if fmt.Sprint(recorder.args) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected bound params, %v", recorder.args)
+	}
+}
+
+func TestStrictConverters(t *testing.T) {
+	if _, err := gmq.ParseBool(sql.RawBytes("yes")); err == nil {
+		t.Errorf("Expected the error for the invalid bool")
+	}
+	if b, err := gmq.ParseBool(sql.RawBytes("t")); err != nil || !b {
+		t.Errorf("Fail to parse the postgres bool, %v, %v", b, err)
+	}
+	if _, err := gmq.ParseInt64(sql.RawBytes("99999999999999999999")); err == nil {
+		t.Errorf("Expected the error for the out of range number")
+	}
+	if n := gmq.AsInt64(sql.RawBytes("99999999999999999999")); n != 0 {
+		t.Errorf("Expected the zero value by the lax converter, got %d", n)
+	}
+	if _, err := gmq.ParseTime(sql.RawBytes("not a time")); err == nil {
+		t.Errorf("Expected the error for the invalid time")
+	}
+	if n, err := gmq.ParseInt(nil); err != nil || n != 0 {
+		t.Errorf("Expected the zero value for NULL, %d, %v", n, err)
+	}
+	if _, err := gmq.ParseIntArray(sql.RawBytes("{1,x}")); err == nil {
+		t.Errorf("Expected the error for the invalid array element")
+	}
+	if ints := gmq.AsIntArray(sql.RawBytes("{x,2}")); len(ints) != 2 || ints[1] != 2 {
+		t.Errorf("Expected the lax array converter to skip the invalid element, got %v", ints)
+	}
+
+	field := ModelField{Name: "State", Type: "ArticleState", Enum: &ModelEnum{Name: "ArticleState", Type: "int"}}
+	if expr := field.ParseFrom("rb"); expr != "gmq.ParseInt(rb)" {
+		t.Errorf("Unexpected parse expression, %s", expr)
+	}
+	if expr := field.WrapValue("value", "rb"); expr != "ArticleState(value)" {
+		t.Errorf("Unexpected wrapped value, %s", expr)
+	}
+	if expr := (ModelField{Type: "string"}).ParseFrom("rb"); expr != "" {
+		t.Errorf("The string conversion never fails, got %s", expr)
+	}
+}
+
+func TestGmqTimeLocation(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	cases := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2015-06-01 10:20:30.123456", time.Date(2015, 6, 1, 10, 20, 30, 123456000, loc)},
+		{"2015-06-01 10:20:30", time.Date(2015, 6, 1, 10, 20, 30, 0, loc)},
+		{"2015-06-01 02:20:30.5+00", time.Date(2015, 6, 1, 10, 20, 30, 500000000, loc)},
+		{"2015-06-01 07:50:30+05:30", time.Date(2015, 6, 1, 10, 20, 30, 0, loc)},
+		{"2015-06-01T02:20:30Z", time.Date(2015, 6, 1, 10, 20, 30, 0, loc)},
+		{"2015-06-01", time.Date(2015, 6, 1, 0, 0, 0, 0, loc)},
+	}
+	for _, cs := range cases {
+		tm, err := gmq.ParseTimeIn(sql.RawBytes(cs.value), loc)
+		if err != nil || !tm.Equal(cs.want) {
+			t.Errorf("Fail to parse the time %q, %s, %v", cs.value, tm, err)
+		}
+	}
+	if tm, err := gmq.ParseTime(sql.RawBytes("0000-00-00 00:00:00")); err != nil || !tm.IsZero() {
+		t.Errorf("Expected the zero time for the MySQL zero date, %s, %v", tm, err)
+	}
+
+	db := gmq.NewDb(nil, "mysql")
+	if gmq.LocationOf(db) != time.UTC {
+		t.Errorf("Expected the default location to be UTC")
+	}
+	db.SetLocation(loc)
+	if gmq.LocationOf(db) != loc {
+		t.Errorf("Expected the location of the db, got %s", gmq.LocationOf(db))
+	}
+}
+
+func TestGmqDecimal(t *testing.T) {
+	cases := [][]string{
+		[]string{"12.5", "12.5"},
+		[]string{"-0.05", "-0.05"},
+		[]string{"1.5e-3", "0.0015"},
+		[]string{"1.5E2", "150"},
+		[]string{".5", "0.5"},
+	}
+	for _, cs := range cases {
+		if d, err := gmq.NewDecimalFromString(cs[0]); err != nil || d.String() != cs[1] {
+			t.Errorf("Fail to parse the decimal %q, %s, %v", cs[0], d, err)
+		}
+	}
+	if _, err := gmq.NewDecimalFromString("1.2.3"); err == nil {
+		t.Errorf("Expected the error for the invalid decimal")
+	}
+	for _, value := range []string{"1e30000000", "1e-30000000", "0.5e-4096", "1e9999999999999999999"} {
+		if _, err := gmq.NewDecimalFromString(value); err == nil {
+			t.Errorf("Expected the error for the decimal out of the scale limit, %s", value)
+		}
+	}
+	var huge gmq.Decimal
+	if err := json.Unmarshal([]byte("1e30000000"), &huge); err == nil {
+		t.Errorf("Expected the error to unmarshal the decimal out of the scale limit")
+	}
+	if d, err := gmq.NewDecimalFromString("1e4096"); err != nil || d.Scale() != 0 {
+		t.Errorf("Fail to parse the decimal in the scale limit, %v", err)
+	}
+
+	d := gmq.AsDecimal(sql.RawBytes("0.1"), 12, 2)
+	if d.String() != "0.10" || d.Scale() != 2 {
+		t.Errorf("Expected the decimal in the column scale, %s", d)
+	}
+	if sum := gmq.NewDecimal(1, 1).Rescale(2); sum.Cmp(d) != 0 {
+		t.Errorf("Expected 0.1 to be equal to 0.10, %s", sum)
+	}
+	if r := gmq.NewDecimal(-125, 2).Rescale(1); r.String() != "-1.3" {
+		t.Errorf("Expected the half away from zero rounding, %s", r)
+	}
+	if _, err := gmq.ParseDecimal(sql.RawBytes("12345.678"), 5, 2); err == nil {
+		t.Errorf("Expected the error for the decimal out of the precision")
+	}
+	if d := gmq.AsDecimal(sql.RawBytes("3.14159"), 0, 0); d.String() != "3.14159" {
+		t.Errorf("Expected the unconstrained numeric to keep the digits, %s", d)
+	}
+
+	data, err := json.Marshal(map[string]gmq.Decimal{"donation": gmq.NewDecimal(1050, 2)})
+	if err != nil || string(data) != `{"donation":10.50}` {
+		t.Errorf("Unexpected json of the decimal, %s, %v", data, err)
+	}
+	var v struct{ Donation gmq.Decimal }
+	if err := json.Unmarshal([]byte(`{"Donation":"0.30"}`), &v); err != nil || v.Donation.String() != "0.30" {
+		t.Errorf("Fail to unmarshal the decimal, %s, %v", v.Donation, err)
+	}
+	if value, err := v.Donation.Value(); err != nil || value != "0.30" {
+		t.Errorf("Unexpected driver value of the decimal, %v, %v", value, err)
+	}
+}
+
+func TestDecimalColumns(t *testing.T) {
+	file, err := ioutil.TempFile("", "modelq_order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString("CREATE TABLE `order` (`id` INT PRIMARY KEY, `amount` DECIMAL(12, 2) NOT NULL, `rate` NUMERIC, `ratio` DOUBLE);")
+	file.Close()
+
+	dbSchema, err := drivers.LoadDdlSchema("mysql", file.Name(), "", "")
+	if err != nil {
+		t.Fatalf("Fail to load the ddl schema, %s", err)
+	}
+	columns := dbSchema["order"]
+	if !columns[1].IsDecimal() || columns[1].NumericPrecision != 12 || columns[1].NumericScale != 2 {
+		t.Errorf("Unexpected decimal column, %+v", columns[1])
+	}
+	if columns[2].NumericPrecision != 10 || columns[3].IsDecimal() {
+		t.Errorf("Unexpected numeric columns, %+v, %+v", columns[2], columns[3])
+	}
+
+	field := newModelField(columns[1])
+	field.setDecimal("gmq", columns[1])
+	if field.Type != "gmq.Decimal" || field.ConvertFrom("rb") != "gmq.AsDecimal(rb, 12, 2)" {
+		t.Errorf("Unexpected decimal field, %s, %s", field.Type, field.ConvertFrom("rb"))
+	}
+	field = newModelField(columns[1])
+	field.setDecimal("github.com/shopspring/decimal.Decimal", columns[1])
+	if field.Type != "decimal.Decimal" || field.Import != "github.com/shopspring/decimal" || field.ParseFrom("rb") != "gmq.ParseScanner[decimal.Decimal](rb)" {
+		t.Errorf("Unexpected user decimal field, %s, %s, %s", field.Type, field.Import, field.ParseFrom("rb"))
+	}
+	field = newModelField(columns[3])
+	if field.setDecimal("gmq", columns[3]); field.Type != "float64" {
+		t.Errorf("The double column should not be the decimal, %s", field.Type)
+	}
+}
+
+func TestJsonTypes(t *testing.T) {
+	columnTypes, err := parseColumnTypes("article.meta=github.com/me/myapp.ArticleMeta, article.extra=*myapp.Extra")
+	if err != nil || len(columnTypes) != 2 || columnTypes["article.extra"] != "*myapp.Extra" {
+		t.Errorf("Unexpected column types, %v, %v", columnTypes, err)
+	}
+	if _, err := parseColumnTypes("meta=myapp.ArticleMeta"); err == nil {
+		t.Errorf("Expected the error for the column without the table")
+	}
+
+	cases := [][]string{
+		[]string{"github.com/me/myapp.ArticleMeta", "myapp.ArticleMeta", "github.com/me/myapp"},
+		[]string{"*github.com/me/myapp.ArticleMeta", "*myapp.ArticleMeta", "github.com/me/myapp"},
+		[]string{"[]myapp.Tag", "[]myapp.Tag", "myapp"},
+		[]string{"ArticleMeta", "ArticleMeta", ""},
+	}
+	for _, cs := range cases {
+		if typeName, importPath := parseGoType(cs[0]); typeName != cs[1] || importPath != cs[2] {
+			t.Errorf("Unexpected go type of %s, %s, %s", cs[0], typeName, importPath)
+		}
+	}
+
+	field := ModelField{Name: "Meta", ColumnName: "meta", Type: "json.RawMessage", IsNullable: true}
+	if err := field.setJsonType("*github.com/me/myapp.ArticleMeta"); err != nil {
+		t.Fatal(err)
+	}
+	if field.setNullable("sql"); field.FieldType() != "*myapp.ArticleMeta" || field.ConvertFrom("rb") != "gmq.AsJsonOf[*myapp.ArticleMeta](rb)" {
+		t.Errorf("Unexpected json field, %s, %s", field.FieldType(), field.ConvertFrom("rb"))
+	}
+	if value := field.SqlValue("p"); value != "gmq.JsonValue(p)" {
+		t.Errorf("Unexpected sql value of the json field, %s", value)
+	}
+	if err := (&ModelField{Type: "string"}).setJsonType("myapp.ArticleMeta"); err == nil {
+		t.Errorf("Expected the error for the column which is not json")
+	}
+
+	type meta struct {
+		Tags []string `json:"tags"`
+	}
+	m := gmq.AsJsonOf[meta](sql.RawBytes(`{"tags":["go","sql"]}`))
+	if len(m.Tags) != 2 || m.Tags[1] != "sql" {
+		t.Errorf("Fail to unmarshal the json column, %+v", m)
+	}
+	if p := gmq.AsJsonOf[*meta](nil); p != nil {
+		t.Errorf("Expected nil for NULL, got %+v", p)
+	}
+	if value, err := gmq.JsonValue(m).Value(); err != nil || value != `{"tags":["go","sql"]}` {
+		t.Errorf("Unexpected json value, %v, %v", value, err)
+	}
+	if value, err := gmq.JsonValue((*meta)(nil)).Value(); err != nil || value != nil {
+		t.Errorf("Expected NULL for the nil pointer, %v, %v", value, err)
+	}
+}
+
+func TestColumnTypeOverrides(t *testing.T) {
+	field := ModelField{Name: "Email", ColumnName: "email", Type: "string"}
+	field.setGoType("net/mail.Address:github.com/me/myconv.AsAddress")
+	if field.Type != "mail.Address" || field.Import != "net/mail" || field.ConverterImport != "github.com/me/myconv" {
+		t.Errorf("Unexpected overridden field, %+v", field)
+	}
+	if field.ConvertFrom("rb") != "myconv.AsAddress(rb)" || field.ParseFrom("rb") != "" {
+		t.Errorf("Unexpected custom converter, %s, %s", field.ConvertFrom("rb"), field.ParseFrom("rb"))
+	}
+
+	field = ModelField{Name: "Nick", ColumnName: "nick", Type: "string"}
+	field.setGoType("github.com/me/myapp.Nick")
+	if field.ConvertFrom("rb") != "gmq.AsScanner[myapp.Nick](rb)" || field.ParseFrom("rb") != "gmq.ParseScanner[myapp.Nick](rb)" {
+		t.Errorf("Unexpected scanner converter, %s, %s", field.ConvertFrom("rb"), field.ParseFrom("rb"))
+	}
+
+	field = ModelField{Name: "Born", ColumnName: "born", Type: "string"}
+	field.setGoType("time.Time")
+	if field.Import != "" || field.ConvertFrom("rb") != "gmq.AsTimeIn(rb, gmq.LocationOf(dbtx))" {
+		t.Errorf("Unexpected builtin type override, %s, %s", field.Import, field.ConvertFrom("rb"))
+	}
+
+	model := ModelMeta{Fields: []ModelField{
+		ModelField{Import: "net/mail", ConverterImport: "github.com/me/myconv"},
+		ModelField{Import: "net/mail"},
+	}}
+	if imports := model.Imports(); len(imports) != 2 || imports[0] != "github.com/me/myconv" || imports[1] != "net/mail" {
+		t.Errorf("Unexpected imports, %v", imports)
+	}
+
+	if v := gmq.AsScanner[sql.NullString](sql.RawBytes("mijia")); !v.Valid || v.String != "mijia" {
+		t.Errorf("Fail to convert by the scanner, %+v", v)
+	}
+	if nick, err := gmq.ParseScanner[sql.NullString](nil); err != nil || nick.Valid {
+		t.Errorf("Expected the invalid value for NULL, %v", err)
+	}
+}
+
+func TestConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "modelq_config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if file := findConfigFile(dir); file != "" {
+		t.Errorf("Expected no config file, got %s", file)
+	}
+
+	os.Setenv("MODELQ_TEST_PASSWORD", "secret")
+	defer os.Unsetenv("MODELQ_TEST_PASSWORD")
+	ioutil.WriteFile(dir+"/modelq.yaml", []byte(`
+driver: mysql
+db: root:${MODELQ_TEST_PASSWORD}@tcp(127.0.0.1:3306)/blog
+schema: blog
+pkg: models
+strict: true
+tables:
+  include: [user, article]
+  exclude: [schema_migrations]
+models:
+  article:
+    name: Post
+    columns:
+      meta: {json_type: github.com/me/myapp.ArticleMeta}
+      email: {type: net/mail.Address, tags: 'validate:"email"'}
+`), 0644)
+	file := findConfigFile(dir)
+	config, err := loadConfig(file)
+	if err != nil {
+		t.Fatalf("Fail to load the config file %s, %s", file, err)
+	}
+	values := config.flagValues()
+	if values["db"] != "root:secret@tcp(127.0.0.1:3306)/blog" || values["tables"] != "user,article,!schema_migrations" || values["strict"] != "true" || values["comment-enums"] != "" {
+		t.Errorf("Unexpected flag values of the config, %v", values)
+	}
+	structNames := map[string]string{"user": "Member"}
+	if config.mergeNames(structNames, map[string]string{}); len(structNames) != 2 || structNames["article"] != "Post" {
+		t.Errorf("Unexpected struct names, %v", structNames)
+	}
+	columnTypes := map[string]string{"article.email": "string"}
+	jsonTypes, columnTags := make(map[string]string), make(map[string]string)
+	config.mergeColumns(columnTypes, jsonTypes, columnTags)
+	if columnTypes["article.email"] != "string" || jsonTypes["article.meta"] != "github.com/me/myapp.ArticleMeta" || columnTags["article.email"] != `validate:"email"` {
+		t.Errorf("Unexpected column settings, %v, %v, %v", columnTypes, jsonTypes, columnTags)
+	}
+
+	ioutil.WriteFile(dir+"/modelq.json", []byte(`{"pkg": "models", "models": {"user": {"name": "Member"}}}`), 0644)
+	if config, err := loadConfig(dir + "/modelq.json"); err != nil || config.Pkg != "models" || config.Models["user"].Name != "Member" {
+		t.Errorf("Unexpected json config, %+v, %v", config, err)
+	}
+	ioutil.WriteFile(dir+"/modelq.json", []byte(`{"package": "models"}`), 0644)
+	if _, err := loadConfig(dir + "/modelq.json"); err == nil {
+		t.Errorf("Expected the error for the unknown key")
+	}
+
+	field := newModelField(drivers.Column{ColumnName: "email"})
+	if field.addTags("`validate:\"email\"`"); field.JsonMeta() != "`json:\"email\" validate:\"email\"`" {
+		t.Errorf("Unexpected tags, %s", field.JsonMeta())
+	}
+	cc := CodeConfig{structNames: map[string]string{"article": "Post"}}
+	if cc.modelName("article") != "Post" || cc.modelName("user_tag") != "UserTag" {
+		t.Errorf("Unexpected model names, %s, %s", cc.modelName("article"), cc.modelName("user_tag"))
+	}
+}
+
+func TestTableFilter(t *testing.T) {
+	filter, err := drivers.NewTableFilter("billing_*, /^log_[0-9]+$/, user, !*_tmp, !schema_migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]bool{
+		"billing_invoice": true, "billing_invoice_tmp": false, "log_201506": true, "log_archive": false,
+		"user": true, "users": false, "schema_migrations": false, "article": false,
+	}
+	for name, expected := range cases {
+		if filter.Match(name) != expected {
+			t.Errorf("Unexpected match of the table %s, expected %v", name, expected)
+		}
+	}
+	if names := filter.Names(); names != "" {
+		t.Errorf("Expected no names for the patterns, got %s", names)
+	}
+
+	filter, _ = drivers.NewTableFilter("!schema_migrations,!*_tmp")
+	if !filter.Match("user") || filter.Match("user_tmp") || filter.Names() != "" {
+		t.Errorf("Expected all the tables except the excluded ones")
+	}
+	filter, _ = drivers.NewTableFilter("user, article, !article")
+	if filter.Names() != "user,article" || filter.Match("article") {
+		t.Errorf("Unexpected names of the filter, %s", filter.Names())
+	}
+	filter, err = drivers.NewTableFilter("/^log_[0-9]{2,3}$/, !/^log_9{2,}$/,user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases = map[string]bool{"log_12": true, "log_123": true, "log_1234": false, "log_99": false, "user": true}
+	for name, expected := range cases {
+		if filter.Match(name) != expected {
+			t.Errorf("Unexpected match of the table %s by the regexps with commas, expected %v", name, expected)
+		}
+	}
+	config := Config{Tables: TablesConfig{Include: []string{"/^log_[0-9]{2,3}$/"}, Exclude: []string{"/^log_9{2,}$/"}}}
+	if filter, err := drivers.NewTableFilter(config.flagValues()["tables"]); err != nil || !filter.Match("log_12") ||
+		filter.Match("log_99") || filter.Match("user") {
+		t.Errorf("Unexpected filter of the config tables, %v", err)
+	}
+	for _, tables := range []string{"/log_(/", "billing_[", "!/[/"} {
+		if _, err := drivers.NewTableFilter(tables); err == nil {
+			t.Errorf("Expected the error for the invalid rule %s", tables)
+		}
+	}
+
+	dbSchema, err := drivers.LoadDdlSchema("sqlite", "examples/blog.sqlite.sql", "main", "!comm*")
+	if err != nil || len(dbSchema) != 2 || dbSchema["comment"] != nil {
+		t.Errorf("Unexpected tables from the ddl schema, %d, %v", len(dbSchema), err)
+	}
+	if _, err := drivers.LoadDdlSchema("sqlite", "examples/blog.sqlite.sql", "main", "/[/"); err == nil {
+		t.Errorf("Expected the error for the invalid table regexp")
+	}
+}
+
+func TestGolintNaming(t *testing.T) {
+	cases := [][]string{
+		[]string{"user_id", "UserID"},
+		[]string{"url", "URL"},
+		[]string{"http_api_key", "HTTPAPIKey"},
+		[]string{"USER", "USER"},
+		[]string{"userId", "UserID"},
+		[]string{"HTTPServer", "HTTPServer"},
+		[]string{"cp_user_124_jiu", "CpUser124Jiu"},
+		[]string{"utf8_name", "UTF8Name"},
+		[]string{"sku_code", "SKUCode"},
+	}
+	initialisms := newInitialisms("sku")
+	for _, cs := range cases {
+		if target := toGolintCase(cs[0], initialisms); target != cs[1] {
+			t.Errorf("src %s, expected %s, got %s", cs[0], cs[1], target)
+		}
+	}
+
+	structNames, fieldNames, err := parseNames("article=Post, article.user_id=AuthorID")
+	if err != nil || structNames["article"] != "Post" || fieldNames["article.user_id"] != "AuthorID" {
+		t.Errorf("Unexpected names, %v, %v, %v", structNames, fieldNames, err)
+	}
+	if _, _, err := parseNames("article=Post,user_id"); err == nil {
+		t.Errorf("Expected the error for the invalid name")
+	}
+	cc := CodeConfig{naming: "golint", structNames: structNames, fieldNames: fieldNames}
+	if name := cc.modelName("api_token"); name != "APIToken" {
+		t.Errorf("Unexpected model name, %s", name)
+	}
+	fields, err := cc.newModelFields(drivers.TableSchema{
+		drivers.Column{TableName: "article", ColumnName: "user_id"},
+		drivers.Column{TableName: "article", ColumnName: "editor_id"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	author, editor := fields[0], fields[1]
+	if author.Name != "AuthorID" || editor.Name != "EditorID" || editor.ParamName() != "editorID" {
+		t.Errorf("Unexpected field names, %s, %s, %s", author.Name, editor.Name, editor.ParamName())
+	}
+	if enum := cc.newModelEnum("PostStatus", []string{"api_error"}); enum.Values[0].Name != "PostStatusAPIError" {
+		t.Errorf("Unexpected enum name, %s", enum.Values[0].Name)
+	}
+}
+
+func TestSingularNames(t *testing.T) {
+	irregulars, err := newIrregulars("cacti=cactus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := [][]string{
+		[]string{"users", "user"},
+		[]string{"blog_posts", "blog_post"},
+		[]string{"categories", "category"},
+		[]string{"addresses", "address"},
+		[]string{"boxes", "box"},
+		[]string{"status", "status"},
+		[]string{"user_statuses", "user_status"},
+		[]string{"people", "person"},
+		[]string{"news", "news"},
+		[]string{"BlogPosts", "BlogPost"},
+		[]string{"USERS", "USER"},
+		[]string{"cacti", "cactus"},
+	}
+	for _, cs := range cases {
+		if target := singularize(cs[0], irregulars); target != cs[1] {
+			t.Errorf("src %s, expected %s, got %s", cs[0], cs[1], target)
+		}
+	}
+	if _, err := newIrregulars("cacti"); err == nil {
+		t.Errorf("Expected the error for the invalid irregular")
+	}
+
+	cc := CodeConfig{singular: true, irregulars: irregulars, stripPrefixes: []string{"tbl_"}, stripSuffixes: []string{"_tab"}}
+	names := [][]string{
+		[]string{"users", "User", "user.go"},
+		[]string{"tbl_orders", "Order", "order.go"},
+		[]string{"TBL_ORDER_ITEMS", "OrderItem", "ORDER_ITEM.go"},
+		[]string{"people_tab", "Person", "person.go"},
+		[]string{"tbl_", "Tbl", "tbl_.go"},
+	}
+	for _, cs := range names {
+		if name, file := cc.modelName(cs[0]), cc.fileName(cs[0]); name != cs[1] || file != cs[2] {
+			t.Errorf("table %s, expected %s in %s, got %s in %s", cs[0], cs[1], cs[2], name, file)
+		}
+	}
+	if plural := cc.pluralize("ProjectPerson"); plural != "ProjectPeople" {
+		t.Errorf("Unexpected plural, %s", plural)
+	}
+	if plural := (CodeConfig{}).pluralize("Person"); plural != "Persons" {
+		t.Errorf("Unexpected plural without the singular names, %s", plural)
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	model := ModelMeta{Name: "User", DbName: "blog", TableName: "tbl_users", config: cc}
+	if err := model.GenHeader(w, nil, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+	if !strings.Contains(buf.String(), "// user.go contains model for the database table [blog.tbl_users]") {
+		t.Errorf("Expected the file name in the header, got %s", buf.String())
+	}
+}
+
+func TestGenerateModelsErrors(t *testing.T) {
+	file, err := ioutil.TempFile("", "modelq_pkg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+	dbSchema, err := drivers.LoadDdlSchema("sqlite", "examples/blog.sqlite.sql", "main", "user,article")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the package directory cannot be created under a file, so none of the models could be generated
+	err = generateModels("main", dbSchema, CodeConfig{packageName: file.Name() + "/models", tags: []string{"json"}})
+	if err == nil || !strings.Contains(err.Error(), "table article") || !strings.Contains(err.Error(), "table user") {
+		t.Errorf("Expected the errors of all the tables, got %v", err)
+	}
+}
+
+func TestSafeIdentifiers(t *testing.T) {
+	cases := [][]string{
+		[]string{"2fa_code", "X2FaCode"},
+		[]string{"café_名字", "Café名字"},
+		[]string{"名字", "X名字"},
+		[]string{"$$", ""},
+	}
+	for _, cs := range cases {
+		if target := exportedName(toCapitalCase(cs[0])); target != cs[1] {
+			t.Errorf("src %s, expected %s, got %s", cs[0], cs[1], target)
+		}
+	}
+
+	columns := []string{"id", "user_id", "USER_ID", "user__id", "string", "2fa", "$$", "type"}
+	schema := make(drivers.TableSchema, len(columns))
+	for i, name := range columns {
+		schema[i] = drivers.Column{TableName: "user", ColumnName: name}
+	}
+	fields, err := CodeConfig{}.newModelFields(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"Id", "UserId", "UserId2", "UserId3", "StringField", "X2Fa", "Column7", "Type"}
+	for i, field := range fields {
+		if field.Name != expected[i] {
+			t.Errorf("column %s, expected %s, got %s", columns[i], expected[i], field.Name)
+		}
+	}
+	if param := fields[7].ParamName(); param != "typeValue" {
+		t.Errorf("Unexpected param name for the keyword, %s", param)
+	}
+
+	cc := CodeConfig{fieldNames: map[string]string{"user.user_id": "Owner", "user.type": "Owner"}}
+	if _, err := cc.newModelFields(schema); err == nil {
+		t.Errorf("Expected the error for the same explicit names")
+	}
+	cc = CodeConfig{fieldNames: map[string]string{"user.user_id": "Get"}}
+	if _, err := cc.newModelFields(schema); err == nil {
+		t.Errorf("Expected the error for the name of the model method")
+	}
+	cc = CodeConfig{fieldNames: map[string]string{"user.user_id": "userID"}}
+	if _, err := cc.newModelFields(schema); err == nil {
+		t.Errorf("Expected the error for the unexported name")
+	}
+	cc = CodeConfig{fieldNames: map[string]string{"user.user__id": "UserId"}}
+	if fields, err := cc.newModelFields(schema); err != nil || fields[1].Name != "UserId2" || fields[3].Name != "UserId" {
+		t.Errorf("Expected the explicit name first, %v", err)
+	}
+
+	if err := (CodeConfig{singular: true}).checkModelNames(drivers.DbSchema{"user": nil, "users": nil}); err == nil {
+		t.Errorf("Expected the error for the same struct names")
+	}
+	if err := (CodeConfig{}).checkModelNames(drivers.DbSchema{"user": nil, "user_objs": nil}); err == nil {
+		t.Errorf("Expected the error for the struct name of the generated var")
+	}
+	if err := (CodeConfig{}).checkModelNames(drivers.DbSchema{"$$": nil}); err == nil {
+		t.Errorf("Expected the error for the table without a name")
+	}
+	if err := (CodeConfig{structNames: map[string]string{"$$": "Money"}}).checkModelNames(drivers.DbSchema{"$$": nil, "user": nil}); err != nil {
+		t.Errorf("Unexpected error for the explicit name, %s", err)
+	}
+
+	files := [][]string{
+		[]string{"user", "user.go"},
+		[]string{"_migrations", "migrations.go"},
+		[]string{"user_test", "user_test_model.go"},
+		[]string{"event_windows", "event_windows_model.go"},
+		[]string{"order items", "order_items.go"},
+		[]string{"名字", "名字.go"},
+	}
+	for _, cs := range files {
+		if file := (CodeConfig{}).fileName(cs[0]); file != cs[1] {
+			t.Errorf("table %s, expected %s, got %s", cs[0], cs[1], file)
+		}
+	}
+}
+
+func TestStructTags(t *testing.T) {
+	col := drivers.Column{TableName: "user", ColumnName: "user_name", DataType: "string", ColumnType: "varchar(50)", CharMaxLength: 50}
+	field := newModelField(col)
+	field.Name = "UserName"
+	if tags := (CodeConfig{}).fieldTags(field, col); len(tags) != 1 || tags[0].Key != "json" || tags[0].Value != "user_name" {
+		t.Errorf("Unexpected default tags, %v", tags)
+	}
+
+	cc := CodeConfig{tags: []string{"json", "db", "yaml", "xml"}, tagCase: "camel", omitempty: true, validate: true}
+	field.Tags = cc.fieldTags(field, col)
+	expected := "`json:\"userName,omitempty\" db:\"user_name\" yaml:\"userName,omitempty\" xml:\"userName,omitempty\" validate:\"max=50\"`"
+	if meta := field.JsonMeta(); meta != expected {
+		t.Errorf("Unexpected tags, %s", meta)
+	}
+	if err := field.addTags(`json:"-" validate:"required,max=20" form:"name"`); err != nil {
+		t.Fatal(err)
+	}
+	if field.Tag("json") != "-" || field.Tag("validate") != "required,max=20" || field.Tag("form") != "name" || len(field.Tags) != 6 {
+		t.Errorf("Unexpected merged tags, %s", field.JsonMeta())
+	}
+
+	tags, err := parseStructTags(`json:"a\"b" db:"c"`)
+	if err != nil || len(tags) != 2 || tags[0].Value != `a"b` || tags[1].Key != "db" {
+		t.Errorf("Unexpected parsed tags, %v, %v", tags, err)
+	}
+	for _, invalid := range []string{`json:"a`, `json`, `json :"a"`, `:"a"`} {
+		if _, err := parseStructTags(invalid); err == nil {
+			t.Errorf("Expected the error for the tags %s", invalid)
+		}
+	}
+
+	dbSchema, err := drivers.LoadDdlSchema("mysql", "examples/blog.mysql.sql", "blog", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := dbSchema["user"][1]; name.CharMaxLength == 0 {
+		t.Errorf("Expected the length of the varchar column, %+v", name)
+	}
+	if id := dbSchema["user"][0]; id.CharMaxLength != 0 {
+		t.Errorf("Expected no length of the int column, %+v", id)
+	}
+}
+
+func TestSensitiveColumns(t *testing.T) {
+	sensitive, err := parseSensitive("user.api_token, user.password")
+	if err != nil || len(sensitive) != 2 || !sensitive["user.password"] {
+		t.Errorf("Unexpected sensitive columns, %v, %v", sensitive, err)
+	}
+	for _, invalid := range []string{"password", "user.", ".password"} {
+		if _, err := parseSensitive(invalid); err == nil {
+			t.Errorf("Expected the error for the sensitive column %s", invalid)
+		}
+	}
+
+	cc := CodeConfig{sensitive: map[string]bool{"user.api_token": true}, tags: []string{"db", "yaml"}}
+	for comment, expected := range map[string]bool{"": false, "bcrypt hash, @sensitive": true, "(@SENSITIVE)": true, "@sensitivee": false} {
+		col := drivers.Column{TableName: "user", ColumnName: "password", Comment: comment}
+		if cc.isSensitive("user", col) != expected {
+			t.Errorf("Unexpected sensitive marker of the comment %q", comment)
+		}
+	}
+	col := drivers.Column{TableName: "user", ColumnName: "api_token", DataType: "string"}
+	if !cc.isSensitive("user", col) {
+		t.Errorf("Expected the sensitive column by the flag")
+	}
+
+	field := newModelField(col)
+	field.IsSensitive = true
+	field.Tags = cc.fieldTags(field, col)
+	if meta := field.JsonMeta(); meta != "`db:\"api_token\" yaml:\"-\" json:\"-\"`" {
+		t.Errorf("Unexpected tags of the sensitive field, %s", meta)
+	}
+	if value := field.SqlValue("p"); value != "gmq.Sensitive(p)" {
+		t.Errorf("Unexpected sql value of the sensitive field, %s", value)
+	}
+
+	params := []interface{}{1, gmq.Sensitive("secret")}
+	if logged := fmt.Sprintf("params=%v", params); logged != "params=[1 [REDACTED]]" {
+		t.Errorf("Unexpected redacted params, %s", logged)
+	}
+
+	dir, err := ioutil.TempDir("", "modelq_sensitive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(dir+"/modelq.json", []byte(`{"models": {"user": {"columns": {"password": {"sensitive": true}}}}}`), 0644)
+	config, err := loadConfig(dir + "/modelq.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.mergeSensitive(sensitive)
+	if !sensitive["user.password"] || !sensitive["user.api_token"] {
+		t.Errorf("Unexpected merged sensitive columns, %v", sensitive)
+	}
+}